@@ -5,6 +5,9 @@ go 1.16
 require (
 	github.com/go-openapi/runtime v0.19.29
 	github.com/go-openapi/strfmt v0.20.1
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
+	github.com/hashicorp/go-uuid v1.0.1
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.7.0
 	github.com/vmware/vra-sdk-go v0.3.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -1,10 +1,12 @@
 package vra
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	neturl "net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -12,7 +14,9 @@ import (
 	"github.com/go-openapi/runtime"
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/login"
 	"github.com/vmware/vra-sdk-go/pkg/models"
@@ -24,6 +28,10 @@ const (
 	DeploymentsAPIVersion = "2019-01-15"
 )
 
+// UserAgent is the User-Agent sent with every API request, optionally followed by the user_agent_suffix
+const UserAgent = "terraform-provider-vra"
+
+// IncreasedTimeOut is the default timeout for API requests when api_timeout is not configured
 const IncreasedTimeOut = 60 * time.Second
 
 type ReauthTimeout struct {
@@ -107,17 +115,107 @@ func (r *ReauthorizeRuntime) Submit(operation *runtime.ClientOperation) (interfa
 
 // Client the VRA Client
 type Client struct {
-	url       string
-	apiClient *client.MulticloudIaaS
+	url          string
+	apiClient    *client.MulticloudIaaS
+	apiTimeout   time.Duration
+	linksFilter  []string
+	requestSlots chan struct{}
+}
+
+// acquireRequestSlot blocks until fewer than max_concurrent_requests provisioning requests are in flight and returns
+// the function that releases the slot, or returns the error of ctx if it is done first. Requests are not limited when
+// max_concurrent_requests is not set.
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.requestSlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.requestSlots <- struct{}{}:
+		return func() { <-c.requestSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// timeoutGetter is implemented by schema.ResourceData
+type timeoutGetter interface {
+	Timeout(key string) time.Duration
+}
+
+// requestTimeout returns the timeout of the API requests made by an operation of a resource. A timeout configured for
+// the operation in the timeouts block of the resource overrides api_timeout. The resource tells a configured timeout
+// apart from an unset one by declaring the timeout of the operation with a zero default.
+func (c *Client) requestTimeout(d timeoutGetter, timeouts *schema.ResourceTimeout, key string) time.Duration {
+	if timeouts == nil {
+		return c.apiTimeout
+	}
+
+	var defaultTimeout *time.Duration
+	switch key {
+	case schema.TimeoutCreate:
+		defaultTimeout = timeouts.Create
+	case schema.TimeoutRead:
+		defaultTimeout = timeouts.Read
+	case schema.TimeoutUpdate:
+		defaultTimeout = timeouts.Update
+	case schema.TimeoutDelete:
+		defaultTimeout = timeouts.Delete
+	}
+
+	if defaultTimeout == nil {
+		return c.apiTimeout
+	}
+	return operationTimeout(d, key, c.apiTimeout)
+}
+
+// operationTimeout returns the timeout configured for an operation of a resource that declares the timeout with a zero
+// default, or fallback when the timeout is not configured
+func operationTimeout(d timeoutGetter, key string, fallback time.Duration) time.Duration {
+	if timeout := d.Timeout(key); timeout > 0 {
+		return timeout
+	}
+	return fallback
+}
+
+// withPage adds the $skip and $top paging query parameters to a list request whose generated parameters do not
+// expose them. The returned function can be passed as the ClientOption of any API client.
+func withPage(skip, top int) func(*runtime.ClientOperation) {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+			if err := r.SetQueryParam("$skip", strconv.Itoa(skip)); err != nil {
+				return err
+			}
+			return r.SetQueryParam("$top", strconv.Itoa(top))
+		})
+	}
+}
+
+// withFilter adds the $filter query parameter to a list request whose generated parameters do not expose it. The
+// returned function can be passed as the ClientOption of any API client.
+func withFilter(filter string) func(*runtime.ClientOperation) {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+			return r.SetQueryParam("$filter", filter)
+		})
+	}
 }
 
 // NewClientFromRefreshToken configures and returns a VRA "Client" struct using "refresh_token" from provider config
-func NewClientFromRefreshToken(url, refreshToken string, insecure bool, reauth string) (interface{}, error) {
+func NewClientFromRefreshToken(url, refreshToken string, insecure bool, reauth string, userAgentSuffix string, conns connectionSettings) (interface{}, error) {
 	token, err := getToken(url, refreshToken, insecure)
 	if err != nil {
 		return "", err
 	}
-	apiClient, err := getAPIClient(url, token, insecure)
+	apiClient, err := getAPIClient(url, token, insecure, userAgentSuffix, conns)
 	if err != nil {
 		return "", err
 	}
@@ -130,16 +228,16 @@ func NewClientFromRefreshToken(url, refreshToken string, insecure bool, reauth s
 	}
 	apiClient.SetTransport(&ReauthorizeRuntime{*t, url, refreshToken, insecure, InitializeTimeout(reautDuration)})
 
-	return &Client{url, apiClient}, nil
+	return &Client{url: url, apiClient: apiClient, apiTimeout: IncreasedTimeOut}, nil
 }
 
 // NewClientFromAccessToken configures and returns a VRA "Client" struct using "access_token" from provider config
-func NewClientFromAccessToken(url, accessToken string, insecure bool) (interface{}, error) {
-	apiClient, err := getAPIClient(url, accessToken, insecure)
+func NewClientFromAccessToken(url, accessToken string, insecure bool, userAgentSuffix string, conns connectionSettings) (interface{}, error) {
+	apiClient, err := getAPIClient(url, accessToken, insecure, userAgentSuffix, conns)
 	if err != nil {
 		return "", err
 	}
-	return &Client{url, apiClient}, nil
+	return &Client{url: url, apiClient: apiClient, apiTimeout: IncreasedTimeOut}, nil
 }
 
 func getToken(url, refreshToken string, insecure bool) (string, error) {
@@ -149,7 +247,7 @@ func getToken(url, refreshToken string, insecure bool) (string, error) {
 	}
 	transport := httptransport.New(parsedURL.Host, parsedURL.Path, nil)
 	transport.SetDebug(false)
-	transport.Transport, err = createTransport(insecure)
+	transport.Transport, err = createTransport(insecure, defaultConnectionSettings)
 	if err != nil {
 		return "", err
 	}
@@ -193,7 +291,20 @@ func (SwaggerLogger) Debugf(format string, args ...interface{}) {
 	}
 }
 
-func createTransport(insecure bool) (http.RoundTripper, error) {
+// connectionSettings configures the reuse of the connections to the API
+type connectionSettings struct {
+	maxIdleConns    int
+	idleConnTimeout time.Duration
+}
+
+// defaultConnectionSettings keeps enough idle connections open for the provider's parallel requests, which all go to
+// the same host
+var defaultConnectionSettings = connectionSettings{
+	maxIdleConns:    100,
+	idleConnTimeout: 90 * time.Second,
+}
+
+func createTransport(insecure bool, conns connectionSettings) (*http.Transport, error) {
 	cfg, err := httptransport.TLSClientAuth(httptransport.TLSClientOptions{
 		InsecureSkipVerify: insecure,
 	})
@@ -201,28 +312,64 @@ func createTransport(insecure bool) (http.RoundTripper, error) {
 		return nil, err
 	}
 
+	// Every request goes to the same host, so the idle connections are not limited per host
 	return &http.Transport{
-		TLSClientConfig: cfg,
-		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig:     cfg,
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConns:        conns.maxIdleConns,
+		MaxIdleConnsPerHost: conns.maxIdleConns,
+		IdleConnTimeout:     conns.idleConnTimeout,
 	}, nil
 }
 
-func getAPIClient(url string, token string, insecure bool) (*client.MulticloudIaaS, error) {
+func getAPIClient(url string, token string, insecure bool, userAgentSuffix string, conns connectionSettings) (*client.MulticloudIaaS, error) {
 	parsedURL, err := neturl.Parse(url)
 	if err != nil {
 		return nil, err
 	}
 	t := httptransport.New(parsedURL.Host, parsedURL.Path, nil)
 	t.DefaultAuthentication = httptransport.APIKeyAuth("Authorization", "header", "Bearer "+token)
-	newTransport, err := createTransport(insecure)
+	newTransport, err := createTransport(insecure, conns)
 	if err != nil {
 		return nil, err
 	}
 
 	// Setup logging through the terraform helper
-	t.Transport = logging.NewTransport("VRA", newTransport)
+	t.Transport = newRequestHeadersTransport(userAgentSuffix, logging.NewTransport("VRA", newTransport))
 	t.SetDebug(true)
 	t.SetLogger(SwaggerLogger{})
 	apiclient := client.New(t, strfmt.Default)
 	return apiclient, nil
 }
+
+// RequestHeadersTransport sets the User-Agent and a unique X-Request-Id header on every request
+type RequestHeadersTransport struct {
+	userAgent string
+	transport http.RoundTripper
+}
+
+func newRequestHeadersTransport(userAgentSuffix string, transport http.RoundTripper) *RequestHeadersTransport {
+	userAgent := UserAgent
+	if userAgentSuffix != "" {
+		userAgent = fmt.Sprintf("%s %s", UserAgent, userAgentSuffix)
+	}
+
+	return &RequestHeadersTransport{userAgent: userAgent, transport: transport}
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *RequestHeadersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	req.Header.Set("X-Request-Id", requestID)
+
+	log.Printf("[DEBUG] %s %s X-Request-Id: %s", req.Method, req.URL.Path, requestID)
+
+	return t.transport.RoundTrip(req)
+}
@@ -1,9 +1,18 @@
 package vra
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 )
 
 func TestClient(t *testing.T) {
@@ -19,7 +28,7 @@ func TestClient(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		apiClient, err := getAPIClient(tt.url, "", true)
+		apiClient, err := getAPIClient(tt.url, "", true, "", defaultConnectionSettings)
 		if err != nil {
 			t.Errorf("getAPIClient returned error %s", err)
 		}
@@ -29,3 +38,166 @@ func TestClient(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestHeadersTransport(t *testing.T) {
+	var tests = []struct {
+		suffix    string
+		userAgent string
+	}{
+		{"", "terraform-provider-vra"},
+		{"platform-team/1.0", "terraform-provider-vra platform-team/1.0"},
+	}
+
+	for _, tt := range tests {
+		var headers []http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = append(headers, r.Header.Clone())
+		}))
+
+		httpClient := &http.Client{Transport: newRequestHeadersTransport(tt.suffix, http.DefaultTransport)}
+		for i := 0; i < 2; i++ {
+			resp, err := httpClient.Get(server.URL)
+			if err != nil {
+				t.Fatalf("request returned error %s", err)
+			}
+			resp.Body.Close()
+		}
+		server.Close()
+
+		for _, h := range headers {
+			if h.Get("User-Agent") != tt.userAgent {
+				t.Errorf("expected User-Agent %q, actual %q", tt.userAgent, h.Get("User-Agent"))
+			}
+			if h.Get("X-Request-Id") == "" {
+				t.Errorf("expected X-Request-Id to be set")
+			}
+		}
+
+		if len(headers) != 2 || headers[0].Get("X-Request-Id") == headers[1].Get("X-Request-Id") {
+			t.Errorf("expected a unique X-Request-Id per request")
+		}
+	}
+}
+
+func TestClient_acquireRequestSlot(t *testing.T) {
+	const maxConcurrentRequests = 3
+	c := &Client{requestSlots: make(chan struct{}, maxConcurrentRequests)}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := c.acquireRequestSlot(context.Background())
+			if err != nil {
+				t.Errorf("acquireRequestSlot returned error %s", err)
+				return
+			}
+			defer release()
+
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > maxConcurrentRequests {
+		t.Errorf("expected at most %d requests in flight, got %d", maxConcurrentRequests, maxInFlight)
+	}
+	if maxInFlight == 0 {
+		t.Errorf("expected requests to be sent")
+	}
+
+	// Without max_concurrent_requests, acquiring a slot never blocks
+	unlimited := &Client{}
+	for i := 0; i < 10; i++ {
+		if _, err := unlimited.acquireRequestSlot(context.Background()); err != nil {
+			t.Errorf("acquireRequestSlot returned error %s", err)
+		}
+	}
+
+	// A cancelled operation stops waiting for a slot
+	full := &Client{requestSlots: make(chan struct{}, 1)}
+	if _, err := full.acquireRequestSlot(context.Background()); err != nil {
+		t.Fatalf("acquireRequestSlot returned error %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := full.acquireRequestSlot(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected acquireRequestSlot to return %s once the context is done, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+// testTimeouts returns the configured timeout of an operation, or the default timeout when none is configured
+type testTimeouts map[string]time.Duration
+
+func (t testTimeouts) Timeout(key string) time.Duration {
+	return t[key]
+}
+
+// testTimeoutRequest records the timeout written to a request by its parameters
+type testTimeoutRequest struct {
+	runtime.ClientRequest
+	timeout time.Duration
+}
+
+func (r *testTimeoutRequest) SetTimeout(timeout time.Duration) error {
+	r.timeout = timeout
+	return nil
+}
+
+func (r *testTimeoutRequest) SetBodyParam(interface{}) error {
+	return nil
+}
+
+func (r *testTimeoutRequest) SetQueryParam(string, ...string) error {
+	return nil
+}
+
+func TestClient_requestTimeout(t *testing.T) {
+	c := &Client{apiTimeout: 90 * time.Second}
+	timeouts := resourceCloudAccountVsphere().Timeouts
+
+	var tests = []struct {
+		name     string
+		d        testTimeouts
+		key      string
+		expected time.Duration
+	}{
+		{"default create timeout", testTimeouts{schema.TimeoutCreate: *timeouts.Create}, schema.TimeoutCreate, 90 * time.Second},
+		{"configured create timeout", testTimeouts{schema.TimeoutCreate: 10 * time.Minute}, schema.TimeoutCreate, 10 * time.Minute},
+		{"configured create timeout of 5m", testTimeouts{schema.TimeoutCreate: 5 * time.Minute}, schema.TimeoutCreate, 5 * time.Minute},
+		{"configured update timeout", testTimeouts{schema.TimeoutUpdate: 2 * time.Minute}, schema.TimeoutUpdate, 2 * time.Minute},
+		{"default delete timeout", testTimeouts{schema.TimeoutDelete: *timeouts.Delete}, schema.TimeoutDelete, 90 * time.Second},
+		{"operation without timeout", testTimeouts{schema.TimeoutRead: 20 * time.Minute}, schema.TimeoutRead, 90 * time.Second},
+	}
+
+	for _, tt := range tests {
+		params := cloud_account.NewCreateVSphereCloudAccountParams().WithTimeout(c.requestTimeout(tt.d, timeouts, tt.key))
+
+		r := &testTimeoutRequest{}
+		if err := params.WriteToRequest(r, strfmt.Default); err != nil {
+			t.Fatalf("%s: WriteToRequest returned error %s", tt.name, err)
+		}
+		if r.timeout != tt.expected {
+			t.Errorf("%s: expected a request timeout of %s, actual %s", tt.name, tt.expected, r.timeout)
+		}
+	}
+
+	if actual := c.requestTimeout(testTimeouts{}, nil, schema.TimeoutCreate); actual != c.apiTimeout {
+		t.Errorf("expected api_timeout for a resource without timeouts, actual %s", actual)
+	}
+}
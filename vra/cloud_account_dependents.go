@@ -0,0 +1,220 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/flavor_profile"
+	"github.com/vmware/vra-sdk-go/pkg/client/image_profile"
+	"github.com/vmware/vra-sdk-go/pkg/client/location"
+	"github.com/vmware/vra-sdk-go/pkg/client/network_profile"
+	"github.com/vmware/vra-sdk-go/pkg/client/storage_profile"
+)
+
+// cloudAccountDependentsPageSize is the number of dependents requested per page
+const cloudAccountDependentsPageSize = 100
+
+// cloudAccountDependent is a resource that references a cloud account and must be deleted before it
+type cloudAccountDependent struct {
+	kind   string
+	id     string
+	delete func() error
+}
+
+// deleteCloudAccountDependents deletes the network, storage, image and flavor profiles and the zones that reference
+// the cloud account, so that the cloud account itself can be deleted. Every dependent is listed, across all pages,
+// before the first one is deleted, since deleting while paging would shift the following pages. Each request is
+// bounded by timeout.
+func deleteCloudAccountDependents(apiClient *client.MulticloudIaaS, cloudAccountID string, timeout time.Duration) error {
+	var dependents []cloudAccountDependent
+
+	lists := []func(*client.MulticloudIaaS, string, time.Duration) ([]cloudAccountDependent, error){
+		listCloudAccountNetworkProfiles,
+		listCloudAccountStorageProfiles,
+		listCloudAccountImageProfiles,
+		listCloudAccountFlavorProfiles,
+		listCloudAccountZones,
+	}
+	for _, list := range lists {
+		listed, err := list(apiClient, cloudAccountID, timeout)
+		if err != nil {
+			return err
+		}
+		dependents = append(dependents, listed...)
+	}
+
+	return deleteDependents(dependents, cloudAccountID)
+}
+
+// deleteCloudAccountZones deletes the zones of the cloud account, such as the zones created for its regions when the
+// cloud account was created with default zones
+func deleteCloudAccountZones(apiClient *client.MulticloudIaaS, cloudAccountID string, timeout time.Duration) error {
+	zones, err := listCloudAccountZones(apiClient, cloudAccountID, timeout)
+	if err != nil {
+		return err
+	}
+
+	return deleteDependents(zones, cloudAccountID)
+}
+
+func deleteDependents(dependents []cloudAccountDependent, cloudAccountID string) error {
+	for _, dependent := range dependents {
+		log.Printf("[INFO] Deleting %s %s of cloud account %s", dependent.kind, dependent.id, cloudAccountID)
+		if err := dependent.delete(); err != nil {
+			return fmt.Errorf("error deleting %s %s: %s", dependent.kind, dependent.id, err)
+		}
+	}
+
+	return nil
+}
+
+func listCloudAccountNetworkProfiles(apiClient *client.MulticloudIaaS, cloudAccountID string, timeout time.Duration) ([]cloudAccountDependent, error) {
+	var dependents []cloudAccountDependent
+	filter := fmt.Sprintf("cloudAccountId eq '%s'", cloudAccountID)
+
+	for skip := 0; ; skip += cloudAccountDependentsPageSize {
+		getResp, err := apiClient.NetworkProfile.GetNetworkProfiles(
+			network_profile.NewGetNetworkProfilesParams().WithTimeout(timeout).WithDollarFilter(withString(filter)),
+			withPage(skip, cloudAccountDependentsPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("error listing network profiles of cloud account %s: %s", cloudAccountID, err)
+		}
+
+		page := getResp.Payload.Content
+		for _, networkProfile := range page {
+			if networkProfile.ID == nil || networkProfile.CloudAccountID != cloudAccountID {
+				continue
+			}
+			id := *networkProfile.ID
+			dependents = append(dependents, cloudAccountDependent{kind: "network profile", id: id, delete: func() error {
+				_, err := apiClient.NetworkProfile.DeleteNetworkProfile(network_profile.NewDeleteNetworkProfileParams().WithTimeout(timeout).WithID(id))
+				return err
+			}})
+		}
+
+		if len(page) < cloudAccountDependentsPageSize {
+			return dependents, nil
+		}
+	}
+}
+
+func listCloudAccountStorageProfiles(apiClient *client.MulticloudIaaS, cloudAccountID string, timeout time.Duration) ([]cloudAccountDependent, error) {
+	var dependents []cloudAccountDependent
+	filter := fmt.Sprintf("cloudAccountId eq '%s'", cloudAccountID)
+
+	for skip := 0; ; skip += cloudAccountDependentsPageSize {
+		getResp, err := apiClient.StorageProfile.GetStorageProfiles(
+			storage_profile.NewGetStorageProfilesParams().WithTimeout(timeout).WithDollarFilter(withString(filter)),
+			withPage(skip, cloudAccountDependentsPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("error listing storage profiles of cloud account %s: %s", cloudAccountID, err)
+		}
+
+		page := getResp.Payload.Content
+		for _, storageProfile := range page {
+			if storageProfile.ID == nil || storageProfile.CloudAccountID != cloudAccountID {
+				continue
+			}
+			id := *storageProfile.ID
+			dependents = append(dependents, cloudAccountDependent{kind: "storage profile", id: id, delete: func() error {
+				_, err := apiClient.StorageProfile.DeleteStorageProfile(storage_profile.NewDeleteStorageProfileParams().WithTimeout(timeout).WithID(id))
+				return err
+			}})
+		}
+
+		if len(page) < cloudAccountDependentsPageSize {
+			return dependents, nil
+		}
+	}
+}
+
+func listCloudAccountImageProfiles(apiClient *client.MulticloudIaaS, cloudAccountID string, timeout time.Duration) ([]cloudAccountDependent, error) {
+	var dependents []cloudAccountDependent
+	filter := fmt.Sprintf("cloudAccountId eq '%s'", cloudAccountID)
+
+	for skip := 0; ; skip += cloudAccountDependentsPageSize {
+		getResp, err := apiClient.ImageProfile.GetImageProfiles(
+			image_profile.NewGetImageProfilesParams().WithTimeout(timeout).WithDollarFilter(withString(filter)),
+			withPage(skip, cloudAccountDependentsPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("error listing image profiles of cloud account %s: %s", cloudAccountID, err)
+		}
+
+		page := getResp.Payload.Content
+		for _, imageProfile := range page {
+			if imageProfile.ID == nil || imageProfile.CloudAccountID != cloudAccountID {
+				continue
+			}
+			id := *imageProfile.ID
+			dependents = append(dependents, cloudAccountDependent{kind: "image profile", id: id, delete: func() error {
+				_, err := apiClient.ImageProfile.DeleteImageProfile(image_profile.NewDeleteImageProfileParams().WithTimeout(timeout).WithID(id))
+				return err
+			}})
+		}
+
+		if len(page) < cloudAccountDependentsPageSize {
+			return dependents, nil
+		}
+	}
+}
+
+func listCloudAccountFlavorProfiles(apiClient *client.MulticloudIaaS, cloudAccountID string, timeout time.Duration) ([]cloudAccountDependent, error) {
+	var dependents []cloudAccountDependent
+	filter := fmt.Sprintf("cloudAccountId eq '%s'", cloudAccountID)
+
+	for skip := 0; ; skip += cloudAccountDependentsPageSize {
+		getResp, err := apiClient.FlavorProfile.GetFlavorProfiles(
+			flavor_profile.NewGetFlavorProfilesParams().WithTimeout(timeout).WithDollarFilter(withString(filter)),
+			withPage(skip, cloudAccountDependentsPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("error listing flavor profiles of cloud account %s: %s", cloudAccountID, err)
+		}
+
+		page := getResp.Payload.Content
+		for _, flavorProfile := range page {
+			if flavorProfile.ID == nil || flavorProfile.CloudAccountID != cloudAccountID {
+				continue
+			}
+			id := *flavorProfile.ID
+			dependents = append(dependents, cloudAccountDependent{kind: "flavor profile", id: id, delete: func() error {
+				_, err := apiClient.FlavorProfile.DeleteFlavorProfile(flavor_profile.NewDeleteFlavorProfileParams().WithTimeout(timeout).WithID(id))
+				return err
+			}})
+		}
+
+		if len(page) < cloudAccountDependentsPageSize {
+			return dependents, nil
+		}
+	}
+}
+
+// listCloudAccountZones lists the zones of the cloud account. The zones API has no filter parameter, so every page of
+// zones is read and filtered here.
+func listCloudAccountZones(apiClient *client.MulticloudIaaS, cloudAccountID string, timeout time.Duration) ([]cloudAccountDependent, error) {
+	var dependents []cloudAccountDependent
+
+	for skip := 0; ; skip += cloudAccountDependentsPageSize {
+		getResp, err := apiClient.Location.GetZones(location.NewGetZonesParams().WithTimeout(timeout), withPage(skip, cloudAccountDependentsPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("error listing zones of cloud account %s: %s", cloudAccountID, err)
+		}
+
+		page := getResp.Payload.Content
+		for _, zone := range page {
+			if zone.ID == nil || zone.CloudAccountID != cloudAccountID {
+				continue
+			}
+			id := *zone.ID
+			dependents = append(dependents, cloudAccountDependent{kind: "zone", id: id, delete: func() error {
+				_, err := apiClient.Location.DeleteZone(location.NewDeleteZoneParams().WithTimeout(timeout).WithID(id))
+				return err
+			}})
+		}
+
+		if len(page) < cloudAccountDependentsPageSize {
+			return dependents, nil
+		}
+	}
+}
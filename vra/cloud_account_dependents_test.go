@@ -0,0 +1,100 @@
+package vra
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDeleteCloudAccountDependents(t *testing.T) {
+	const cloudAccountID = "ca-1"
+
+	// Two pages of zones, the zones of the cloud account are on both pages
+	var zones []map[string]interface{}
+	for i := 0; i < cloudAccountDependentsPageSize+2; i++ {
+		accountID := "ca-other"
+		if i == 0 || i == cloudAccountDependentsPageSize+1 {
+			accountID = cloudAccountID
+		}
+		zones = append(zones, map[string]interface{}{"id": fmt.Sprintf("zone-%d", i), "name": "zone", "cloudAccountId": accountID})
+	}
+
+	profiles := map[string][]map[string]interface{}{
+		"/iaas/api/network-profiles": {{"id": "np-1", "name": "np", "cloudAccountId": cloudAccountID}},
+		"/iaas/api/storage-profiles": {{"id": "sp-1", "cloudAccountId": cloudAccountID, "defaultItem": false}},
+		"/iaas/api/image-profiles":   {{"id": "ip-1", "name": "ip", "cloudAccountId": cloudAccountID}, {"id": "ip-2", "name": "ip", "cloudAccountId": "ca-other"}},
+		"/iaas/api/flavor-profiles":  {{"id": "fp-1", "name": "fp", "cloudAccountId": cloudAccountID}},
+		"/iaas/api/zones":            zones,
+	}
+
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodDelete {
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		content, ok := profiles[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+		top, _ := strconv.Atoi(r.URL.Query().Get("$top"))
+		end := skip + top
+		if end > len(content) {
+			end = len(content)
+		}
+		if skip > len(content) {
+			skip = len(content)
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"content":       content[skip:end],
+			"totalElements": len(content),
+		})
+	}))
+	defer server.Close()
+
+	apiClient, err := getAPIClient(server.URL, "token", true, "", defaultConnectionSettings)
+	if err != nil {
+		t.Fatalf("getAPIClient returned error %s", err)
+	}
+
+	if err := deleteCloudAccountDependents(apiClient, cloudAccountID, IncreasedTimeOut); err != nil {
+		t.Fatalf("deleteCloudAccountDependents returned error %s", err)
+	}
+
+	expected := []string{
+		"/iaas/api/flavor-profiles/fp-1",
+		"/iaas/api/image-profiles/ip-1",
+		"/iaas/api/network-profiles/np-1",
+		"/iaas/api/storage-profiles/sp-1",
+		"/iaas/api/zones/zone-0",
+		fmt.Sprintf("/iaas/api/zones/zone-%d", cloudAccountDependentsPageSize+1),
+	}
+	// The zones are deleted last, once no profile references their regions
+	for i, path := range deleted {
+		if strings.HasPrefix(path, "/iaas/api/zones/") && i < len(deleted)-2 {
+			t.Errorf("expected the zones to be deleted last, got %v", deleted)
+		}
+	}
+
+	sort.Strings(deleted)
+	if strings.Join(deleted, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected the dependents %v to be deleted, got %v", expected, deleted)
+	}
+}
@@ -0,0 +1,51 @@
+package vra
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+)
+
+// createDefaultZonesSchema returns the schema to use for the create_default_zones property of the cloud account
+// resources. The zones are only created with the cloud account, so changing it later has no effect.
+func createDefaultZonesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Create a zone for each region of the cloud account when it is created.",
+	}
+}
+
+// cloudAccountZoneIdsSchema returns the schema to use for the zone_ids property of the cloud account resources
+func cloudAccountZoneIdsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Description: "The ids of the zones of the cloud account, read when create_default_zones is set.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// setCloudAccountZoneIds sets zone_ids to the zones of the cloud account. The zones are only listed when
+// create_default_zones is set, so that reading the other cloud accounts does not list every zone.
+func setCloudAccountZoneIds(d *schema.ResourceData, apiClient *client.MulticloudIaaS, timeout time.Duration) error {
+	if !d.Get("create_default_zones").(bool) {
+		return d.Set("zone_ids", []string{})
+	}
+
+	zones, err := listCloudAccountZones(apiClient, d.Id(), timeout)
+	if err != nil {
+		return err
+	}
+
+	zoneIds := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		zoneIds = append(zoneIds, zone.id)
+	}
+
+	return d.Set("zone_ids", zoneIds)
+}
@@ -1,5 +1,7 @@
 package vra
 
+import "strings"
+
 func expandCustomProperties(configCustomProperties map[string]interface{}) map[string]string {
 	customProperties := make(map[string]string)
 
@@ -9,3 +11,23 @@ func expandCustomProperties(configCustomProperties map[string]interface{}) map[s
 
 	return customProperties
 }
+
+// flattenCustomProperties returns the custom properties to store in state. vRA adds its own properties to the ones
+// set in the provisioning request, so only the configured keys are kept when custom properties are managed. Otherwise,
+// for example after an import, every property except the internal ones prefixed with "__" is kept.
+func flattenCustomProperties(configCustomProperties map[string]interface{}, customProperties map[string]string) map[string]string {
+	flattened := make(map[string]string)
+
+	for key, value := range customProperties {
+		if len(configCustomProperties) > 0 {
+			if _, ok := configCustomProperties[key]; !ok {
+				continue
+			}
+		} else if strings.HasPrefix(key, "__") {
+			continue
+		}
+		flattened[key] = value
+	}
+
+	return flattened
+}
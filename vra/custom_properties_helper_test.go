@@ -0,0 +1,48 @@
+package vra
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenCustomProperties(t *testing.T) {
+	customProperties := map[string]string{
+		"image":          "ubuntu",
+		"hostname":       "web-01",
+		"__computeType":  "VirtualMachine",
+		"__placementLog": "zone-a",
+	}
+
+	var tests = []struct {
+		name     string
+		config   map[string]interface{}
+		expected map[string]string
+	}{
+		{
+			"user-set properties only",
+			map[string]interface{}{"hostname": "web-00"},
+			map[string]string{"hostname": "web-01"},
+		},
+		{
+			"user-set internal property",
+			map[string]interface{}{"__computeType": "VirtualMachine"},
+			map[string]string{"__computeType": "VirtualMachine"},
+		},
+		{
+			"user-set property removed by the API",
+			map[string]interface{}{"owner": "platform"},
+			map[string]string{},
+		},
+		{
+			"no user-set properties",
+			map[string]interface{}{},
+			map[string]string{"image": "ubuntu", "hostname": "web-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		if actual := flattenCustomProperties(tt.config, customProperties); !reflect.DeepEqual(actual, tt.expected) {
+			t.Errorf("%s: expected %v, actual %v", tt.name, tt.expected, actual)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/blueprint"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -144,6 +145,30 @@ func dataSourceBlueprint() *schema.Resource {
 					},
 				},
 			},
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -159,69 +184,114 @@ func dataSourceBlueprintRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("one of id or name must be assigned")
 	}
 
-	var resp *blueprint.ListBlueprintsUsingGET1OK
-	var err error
-	projects := make([]string, 1)
-
-	if projectIDOk {
-		projects = append(projects, projectID.(string))
-		resp, err = apiClient.Blueprint.ListBlueprintsUsingGET1(
-			blueprint.NewListBlueprintsUsingGET1Params().WithName(withString(name.(string))).WithProjects(projects))
+	var bpID string
+	if idOk {
+		bpID = id.(string)
 	} else {
-		resp, err = apiClient.Blueprint.ListBlueprintsUsingGET1(
-			blueprint.NewListBlueprintsUsingGET1Params().WithName(withString(name.(string))))
+		params := blueprint.NewListBlueprintsUsingGET1Params().WithName(withString(name.(string)))
+		if projectIDOk {
+			params = params.WithProjects([]string{projectID.(string)})
+		}
+
+		resp, err := apiClient.Blueprint.ListBlueprintsUsingGET1(params)
+		if err != nil {
+			return err
+		}
+
+		matches := make([]string, 0)
+		for _, bp := range resp.GetPayload().Content {
+			if bp.Name == name.(string) {
+				matches = append(matches, bp.ID)
+			}
+		}
+
+		if len(matches) == 0 {
+			return fmt.Errorf("blueprint %s not found", name)
+		}
+
+		if len(matches) > 1 {
+			return fmt.Errorf("more than one blueprint found with the name %s, try to narrow filter by project_id", name)
+		}
+
+		bpID = matches[0]
 	}
 
+	bpDetails, err := apiClient.Blueprint.GetBlueprintUsingGET1(
+		blueprint.NewGetBlueprintUsingGET1Params().WithBlueprintID(strfmt.UUID(bpID)))
 	if err != nil {
 		return err
 	}
 
-	if resp.GetPayload().NumberOfElements == 0 {
-		return fmt.Errorf("blueprint %s not found", name)
+	versions, err := listBlueprintVersions(apiClient, bpID)
+	if err != nil {
+		return err
 	}
 
-	if resp.GetPayload().NumberOfElements > 1 {
-		return fmt.Errorf("more than one blueprint found with the same name, try to narrow filter by project_id")
-	}
+	bp := bpDetails.GetPayload()
+	d.SetId(bp.ID)
+	d.Set("content", bp.Content)
+	d.Set("content_source_id", bp.ContentSourceID)
+	d.Set("content_source_path", bp.ContentSourcePath)
+	d.Set("content_source_sync_at", bp.ContentSourceSyncAt)
+	d.Set("content_source_sync_messages", bp.ContentSourceSyncMessages)
+	d.Set("content_source_sync_status", bp.ContentSourceSyncStatus)
+	d.Set("content_source_type", bp.ContentSourceType)
+	d.Set("created_at", bp.CreatedAt)
+	d.Set("created_by", bp.CreatedBy)
+	d.Set("description", bp.Description)
+	d.Set("name", bp.Name)
+	d.Set("org_id", bp.OrgID)
+	d.Set("project_id", bp.ProjectID)
+	d.Set("project_name", bp.ProjectName)
+	d.Set("request_scope_org", bp.RequestScopeOrg)
+	d.Set("self_link", bp.SelfLink)
+	d.Set("status", bp.Status)
+	d.Set("total_released_versions", bp.TotalReleasedVersions)
+	d.Set("total_versions", bp.TotalVersions)
+	d.Set("updated_at", bp.UpdatedAt)
+	d.Set("updated_by", bp.UpdatedBy)
+	d.Set("valid", bp.Valid)
 
-	setFields := func(blueprint *models.Blueprint) {
-		d.SetId(blueprint.ID)
-		d.Set("content", blueprint.Content)
-		d.Set("content_source_id", blueprint.ContentSourceID)
-		d.Set("content_source_pat", blueprint.ContentSourcePath)
-		d.Set("content_source_sync_at", blueprint.ContentSourceSyncAt)
-		d.Set("content_source_sync_messages", blueprint.ContentSourceSyncMessages)
-		d.Set("content_source_sync_status", blueprint.ContentSourceSyncStatus)
-		d.Set("content_source_type", blueprint.ContentSourceType)
-		d.Set("created_at", blueprint.CreatedAt)
-		d.Set("created_by", blueprint.CreatedBy)
-		d.Set("description", blueprint.Description)
-		d.Set("name", blueprint.Name)
-		d.Set("org_id", blueprint.OrgID)
-		d.Set("project_id", blueprint.ProjectID)
-		d.Set("project_name", blueprint.ProjectName)
-		d.Set("request_scope_org", blueprint.RequestScopeOrg)
-		d.Set("self_link", blueprint.SelfLink)
-		d.Set("status", blueprint.Status)
-		d.Set("total_released_versions", blueprint.TotalReleasedVersions)
-		d.Set("total_versions", blueprint.TotalVersions)
-		d.Set("updated_at", blueprint.UpdatedAt)
-		d.Set("updated_by", blueprint.UpdatedBy)
-		d.Set("valid", blueprint.Valid)
+	if err := d.Set("versions", flattenBlueprintVersions(versions)); err != nil {
+		return fmt.Errorf("error setting blueprint versions - error: %#v", err)
 	}
 
-	for _, bp := range resp.Payload.Content {
-		if (idOk && bp.ID == id) || (nameOk && bp.Name == name.(string)) {
-			bpDetails, err := apiClient.Blueprint.GetBlueprintUsingGET1(
-				blueprint.NewGetBlueprintUsingGET1Params().WithBlueprintID(strfmt.UUID(bp.ID)))
-			if err != nil {
-				return err
-			}
-			setFields(bpDetails.GetPayload())
+	return nil
+}
+
+// listBlueprintVersions returns all the versions of the blueprint, following the pages of the API response
+func listBlueprintVersions(apiClient *client.MulticloudIaaS, blueprintID string) ([]*models.BlueprintVersion, error) {
+	versions := make([]*models.BlueprintVersion, 0)
+
+	for {
+		resp, err := apiClient.Blueprint.ListBlueprintVersionsUsingGET(
+			blueprint.NewListBlueprintVersionsUsingGETParams().
+				WithBlueprintID(strfmt.UUID(blueprintID)).
+				WithDollarSkip(withInt32(int32(len(versions)))))
+		if err != nil {
+			return nil, err
+		}
 
-			return nil
+		page := resp.GetPayload()
+		versions = append(versions, page.Content...)
+
+		if page.Last || len(page.Content) == 0 || int64(len(versions)) >= page.TotalElements {
+			return versions, nil
 		}
 	}
+}
+
+func flattenBlueprintVersions(versions []*models.BlueprintVersion) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(versions))
+
+	for _, version := range versions {
+		flattened = append(flattened, map[string]interface{}{
+			"created_at": version.CreatedAt.String(),
+			"id":         version.ID,
+			"status":     version.Status,
+			"version":    version.Version,
+		})
+	}
 
-	return fmt.Errorf("blueprint %s not found", name)
+	return flattened
 }
@@ -39,6 +39,30 @@ func TestAccDataSourceVRABlueprint_Found(t *testing.T) {
 					resource.TestCheckResourceAttrPair(dataSource, "description", resource1, "description"),
 					resource.TestCheckResourceAttrPair(dataSource, "content", resource1, "content"),
 					resource.TestCheckResourceAttrPair(dataSource, "project_id", resource1, "project_id"),
+					resource.TestCheckResourceAttr(dataSource, "versions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceVRABlueprint_ByID(t *testing.T) {
+	resource1 := "vra_blueprint.this"
+	dataSource := "data.vra_blueprint.this"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckBlueprint(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVRABlueprintByID(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSource, "name", resource1, "name"),
+					resource.TestCheckResourceAttrPair(dataSource, "content", resource1, "content"),
+					resource.TestCheckResourceAttrPair(dataSource, "self_link", resource1, "self_link"),
+					resource.TestCheckResourceAttr(dataSource, "versions.#", "1"),
+					resource.TestCheckResourceAttr(dataSource, "versions.0.version", "1"),
+					resource.TestCheckResourceAttr(dataSource, "versions.0.status", "RELEASED"),
 				),
 			},
 		},
@@ -78,3 +102,17 @@ func testAccDataSourceVRABlueprintFound() string {
 			name = vra_blueprint.this.name
 		}`
 }
+
+func testAccDataSourceVRABlueprintByID() string {
+	rInt := acctest.RandInt()
+	return testAccDataSourceVRABlueprintBase(rInt) + `
+		resource "vra_blueprint_version" "this" {
+			blueprint_id = vra_blueprint.this.id
+			version      = "1"
+			release      = true
+		}
+
+		data "vra_blueprint" "this" {
+			id = vra_blueprint_version.this.blueprint_id
+		}`
+}
@@ -0,0 +1,84 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client/catalog_items"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// catalogItemVersionsPageSize is the number of catalog item versions requested per page
+const catalogItemVersionsPageSize = 100
+
+func dataSourceCatalogItemVersion() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCatalogItemVersionRead,
+
+		Schema: map[string]*schema.Schema{
+			"catalog_item_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the catalog item.",
+			},
+			"latest_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the most recently created version, or an empty string when the catalog item has no released versions.",
+			},
+			"versions": catalogItemVersionSchema(),
+		},
+	}
+}
+
+func dataSourceCatalogItemVersionRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading the vra_catalog_item_version data source")
+	apiClient := meta.(*Client).apiClient
+
+	catalogItemID := d.Get("catalog_item_id").(string)
+	if !strfmt.IsUUID(catalogItemID) {
+		return fmt.Errorf("catalog_item_id %q is not a valid catalog item id", catalogItemID)
+	}
+
+	versions := make([]*models.CatalogItemVersion, 0)
+	for page := int32(0); ; page++ {
+		getResp, err := apiClient.CatalogItems.GetVersionsUsingGET(
+			catalog_items.NewGetVersionsUsingGETParams().
+				WithID(strfmt.UUID(catalogItemID)).
+				WithPage(withInt32(page)).
+				WithSize(withInt32(catalogItemVersionsPageSize)))
+		if err != nil {
+			return err
+		}
+
+		content := getResp.GetPayload().Content
+		versions = append(versions, content...)
+
+		if len(content) == 0 || int64(len(versions)) >= getResp.GetPayload().TotalElements {
+			break
+		}
+	}
+
+	d.SetId(catalogItemID)
+	d.Set("latest_version", latestCatalogItemVersion(versions))
+	if err := d.Set("versions", flattenCatalogItemVersions(versions)); err != nil {
+		return fmt.Errorf("error setting catalog item versions - error: %#v", err)
+	}
+
+	return nil
+}
+
+// latestCatalogItemVersion returns the id of the most recently created version, or an empty string if there is none
+func latestCatalogItemVersion(versions []*models.CatalogItemVersion) string {
+	latest := ""
+	var latestCreatedAt time.Time
+	for _, version := range versions {
+		if createdAt := time.Time(version.CreatedAt); latest == "" || createdAt.After(latestCreatedAt) {
+			latest, latestCreatedAt = version.ID, createdAt
+		}
+	}
+	return latest
+}
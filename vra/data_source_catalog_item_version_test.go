@@ -0,0 +1,61 @@
+package vra
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestAccDataSourceVRACatalogItemVersion(t *testing.T) {
+	dataSource := "data.vra_catalog_item_version.this"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckCatalogItem(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVRACatalogItemVersion(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSource, "catalog_item_id", "data.vra_catalog_item.this", "id"),
+					resource.TestCheckResourceAttrSet(dataSource, "versions.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVRACatalogItemVersion() string {
+	return fmt.Sprintf(`
+	data "vra_catalog_item" "this" {
+	  name = "%s"
+	}
+
+	data "vra_catalog_item_version" "this" {
+	  catalog_item_id = data.vra_catalog_item.this.id
+	}`, os.Getenv("VRA_CATALOG_ITEM_NAME"))
+}
+
+func TestLatestCatalogItemVersion(t *testing.T) {
+	created := func(s string) strfmt.DateTime {
+		createdAt, _ := time.Parse(time.RFC3339, s)
+		return strfmt.DateTime(createdAt)
+	}
+
+	if latest := latestCatalogItemVersion(nil); latest != "" {
+		t.Errorf("expected no latest version without versions, got %q", latest)
+	}
+
+	versions := []*models.CatalogItemVersion{
+		{ID: "1", CreatedAt: created("2021-01-01T00:00:00Z")},
+		{ID: "3", CreatedAt: created("2021-03-01T00:00:00Z")},
+		{ID: "2", CreatedAt: created("2021-02-01T00:00:00Z")},
+	}
+	if latest := latestCatalogItemVersion(versions); latest != "3" {
+		t.Errorf("expected the latest version to be 3, got %q", latest)
+	}
+}
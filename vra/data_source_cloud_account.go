@@ -0,0 +1,148 @@
+package vra
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func dataSourceCloudAccount() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudAccountRead,
+
+		Schema: map[string]*schema.Schema{
+			"cloud_account_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the cloud account, for example vsphere, aws or nsxt.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date when the entity was created. The date is in ISO 8601 and UTC.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A human-friendly description.",
+			},
+			"enabled_region_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The ids of the regions enabled on the cloud account.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"filter": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id", "name"},
+				Description:   "Search criteria to narrow down the cloud accounts, for example \"cloudAccountType eq 'vsphere'\".",
+			},
+			"id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"filter", "name"},
+				Description:   "The id of the cloud account.",
+			},
+			"links": linksSchema(),
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"filter", "id"},
+				Description:   "The name of the cloud account.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the organization this entity belongs to.",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Email of the user that owns the entity.",
+			},
+			"tags": tagsSchema(),
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date when the entity was last updated. The date is ISO 8601 and UTC.",
+			},
+		},
+	}
+}
+
+func dataSourceCloudAccountRead(d *schema.ResourceData, meta interface{}) error {
+	apiClient := meta.(*Client).apiClient
+
+	id, idOk := d.GetOk("id")
+	name, nameOk := d.GetOk("name")
+	filter, filterOk := d.GetOk("filter")
+
+	if !idOk && !nameOk && !filterOk {
+		return fmt.Errorf("one of the following are required: (`id`, `name` or `filter`)")
+	}
+
+	setFields := func(account *models.CloudAccount) error {
+		d.SetId(*account.ID)
+		d.Set("cloud_account_type", account.CloudAccountType)
+		d.Set("created_at", account.CreatedAt)
+		d.Set("description", account.Description)
+		d.Set("enabled_region_ids", account.EnabledRegionIds)
+		d.Set("name", account.Name)
+		d.Set("org_id", account.OrgID)
+		d.Set("owner", account.Owner)
+		d.Set("updated_at", account.UpdatedAt)
+
+		if err := d.Set("links", flattenLinks(filterLinks(account.Links, meta.(*Client).linksFilter))); err != nil {
+			return fmt.Errorf("error setting cloud account links - error: %#v", err)
+		}
+
+		if err := d.Set("tags", flattenTags(account.Tags)); err != nil {
+			return fmt.Errorf("error setting cloud account tags - error: %#v", err)
+		}
+
+		return nil
+	}
+
+	if idOk {
+		getResp, err := apiClient.CloudAccount.GetCloudAccount(cloud_account.NewGetCloudAccountParams().WithID(id.(string)))
+		if err != nil {
+			switch err.(type) {
+			case *cloud_account.GetCloudAccountNotFound:
+				return fmt.Errorf("cloud account %s not found", id.(string))
+			default:
+				return err
+			}
+		}
+
+		return setFields(getResp.Payload)
+	}
+
+	if nameOk {
+		filter = fmt.Sprintf("name eq '%s'", strings.ReplaceAll(name.(string), "'", "''"))
+	}
+
+	getResp, err := apiClient.CloudAccount.GetCloudAccounts(cloud_account.NewGetCloudAccountsParams().WithDollarFilter(withString(filter.(string))))
+	if err != nil {
+		return err
+	}
+
+	cloudAccounts := getResp.Payload.Content
+	switch {
+	case len(cloudAccounts) == 0 && nameOk:
+		return fmt.Errorf("cloud account %s not found", name.(string))
+	case len(cloudAccounts) == 0:
+		return fmt.Errorf("vra_cloud_account filter did not match any cloud accounts")
+	case len(cloudAccounts) > 1:
+		return fmt.Errorf("vra_cloud_account must match a single cloud account, %d cloud accounts found", len(cloudAccounts))
+	}
+
+	return setFields(cloudAccounts[0])
+}
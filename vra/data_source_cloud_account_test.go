@@ -0,0 +1,91 @@
+package vra
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceCloudAccountRead(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	ds := dataSourceCloudAccount()
+
+	cloudAccounts := map[string]map[string]interface{}{
+		"vsphere": {
+			"id":               "cloud-account-1",
+			"name":             "vsphere",
+			"cloudAccountType": "vsphere",
+			"enabledRegionIds": []interface{}{"region-1"},
+			"tags":             []interface{}{map[string]interface{}{"key": "env", "value": "prod"}},
+			"_links":           map[string]interface{}{"self": map[string]interface{}{"href": "/iaas/api/cloud-accounts/cloud-account-1"}},
+		},
+		"aws": {
+			"id":               "cloud-account-2",
+			"name":             "o'aws",
+			"cloudAccountType": "aws",
+		},
+	}
+	var filters []string
+	api.handle(http.MethodGet, "/iaas/api/cloud-accounts", func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("$filter")
+		filters = append(filters, filter)
+
+		content := make([]interface{}, 0)
+		switch filter {
+		case "name eq 'vsphere'", "cloudAccountType eq 'vsphere'":
+			content = append(content, cloudAccounts["vsphere"])
+		case "name eq 'o''aws'":
+			content = append(content, cloudAccounts["aws"])
+		case "cloudAccountType ne 'nsxt'":
+			content = append(content, cloudAccounts["vsphere"], cloudAccounts["aws"])
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": content, "totalElements": len(content)})
+	})
+
+	var tests = []struct {
+		config       map[string]interface{}
+		expectedID   string
+		expectedType string
+		expectedErr  string
+	}{
+		{map[string]interface{}{"name": "vsphere"}, "cloud-account-1", "vsphere", ""},
+		{map[string]interface{}{"name": "o'aws"}, "cloud-account-2", "aws", ""},
+		{map[string]interface{}{"filter": "cloudAccountType eq 'vsphere'"}, "cloud-account-1", "vsphere", ""},
+		{map[string]interface{}{"name": "missing"}, "", "", "cloud account missing not found"},
+		{map[string]interface{}{"filter": "cloudAccountType eq 'gcp'"}, "", "", "vra_cloud_account filter did not match any cloud accounts"},
+		{map[string]interface{}{"filter": "cloudAccountType ne 'nsxt'"}, "", "", "vra_cloud_account must match a single cloud account, 2 cloud accounts found"},
+		{map[string]interface{}{}, "", "", "one of the following are required: (`id`, `name` or `filter`)"},
+	}
+
+	for _, tt := range tests {
+		d := ds.TestResourceData()
+		for key, value := range tt.config {
+			d.Set(key, value)
+		}
+
+		err := ds.Read(d, c)
+		if tt.expectedErr != "" {
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("%v: expected error %q, got %v", tt.config, tt.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: read returned error %s", tt.config, err)
+		}
+		if d.Id() != tt.expectedID || d.Get("cloud_account_type") != tt.expectedType {
+			t.Errorf("%v: expected cloud account %s of type %s, got %s of type %s", tt.config, tt.expectedID, tt.expectedType, d.Id(), d.Get("cloud_account_type"))
+		}
+	}
+
+	d := ds.TestResourceData()
+	d.Set("name", "vsphere")
+	if err := ds.Read(d, c); err != nil {
+		t.Fatalf("read returned error %s", err)
+	}
+	if d.Get("tags").(*schema.Set).Len() != 1 || d.Get("enabled_region_ids").(*schema.Set).Len() != 1 {
+		t.Errorf("expected the tags and enabled regions of the cloud account to be set")
+	}
+}
@@ -123,6 +123,14 @@ func dataSourceDeployment() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags of the deployment to look up, in the format key:value.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -133,9 +141,11 @@ func dataSourceDeploymentRead(d *schema.ResourceData, m interface{}) error {
 
 	id, idOk := d.GetOk("id")
 	name, nameOk := d.GetOk("name")
+	projectID, projectIDOk := d.GetOk("project_id")
+	tags, tagsOk := d.GetOk("tags")
 
-	if !idOk && !nameOk {
-		return fmt.Errorf("one of id or name must be assigned")
+	if !idOk && !nameOk && !projectIDOk && !tagsOk {
+		return fmt.Errorf("one of id, name, project_id or tags must be assigned")
 	}
 
 	expandLastRequest := d.Get("expand_last_request").(bool)
@@ -182,19 +192,39 @@ func dataSourceDeploymentRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
-	if nameOk {
-		getAllResp, err := apiClient.Deployments.GetDeploymentsUsingGET(
-			deployments.NewGetDeploymentsUsingGETParams().WithName(withString(name.(string))))
+	if !idOk {
+		getAllParams := deployments.NewGetDeploymentsUsingGETParams().WithAPIVersion(withString(DeploymentsAPIVersion))
+		if nameOk {
+			getAllParams = getAllParams.WithName(withString(name.(string)))
+		}
+		if projectIDOk {
+			getAllParams = getAllParams.WithProjects([]string{projectID.(string)})
+		}
+		if tagsOk {
+			getAllParams = getAllParams.WithTags(expandStringList(tags.(*schema.Set).List()))
+		}
 
+		getAllResp, err := apiClient.Deployments.GetDeploymentsUsingGET(getAllParams)
 		if err != nil {
 			return err
 		}
 
-		if getAllResp.Payload.NumberOfElements == 1 {
-			deployment := getAllResp.Payload.Content[0]
-			id = deployment.ID.String()
-		} else {
-			return fmt.Errorf("deployment %s not found", name)
+		// The response holds only the first page of the matching deployments, so the total number of matches is reported
+		matches := int64(len(getAllResp.Payload.Content))
+		if getAllResp.Payload.TotalElements > matches {
+			matches = getAllResp.Payload.TotalElements
+		}
+
+		switch {
+		case matches == 0:
+			if nameOk {
+				return fmt.Errorf("deployment %s not found", name)
+			}
+			return fmt.Errorf("vra_deployment did not match any deployments")
+		case matches == 1:
+			id = getAllResp.Payload.Content[0].ID.String()
+		default:
+			return fmt.Errorf("vra_deployment must match a single deployment, %d deployments found", matches)
 		}
 	}
 
@@ -206,7 +236,7 @@ func dataSourceDeploymentRead(d *schema.ResourceData, m interface{}) error {
 			WithExpandResources(withBool(expandResources)).
 			WithExpandLastRequest(withBool(expandLastRequest)).
 			WithAPIVersion(withString(DeploymentsAPIVersion)).
-			WithTimeout(IncreasedTimeOut))
+			WithTimeout(m.(*Client).apiTimeout))
 
 	if err != nil {
 		return err
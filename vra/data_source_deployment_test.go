@@ -2,9 +2,11 @@ package vra
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"regexp"
 	"testing"
@@ -77,3 +79,61 @@ func testAccDataSourceVRADeploymentOneConfig(rInt int) string {
 			name = "${vra_deployment.this.name}"
 		}`
 }
+
+func TestDataSourceDeploymentRead(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+
+	const deploymentID = "f0d0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6"
+	var found []interface{}
+	var totalElements int
+	api.handle(http.MethodGet, "/deployment/api/deployments", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("projects") != "project-1" || query.Get("tags") != "env:test" {
+			t.Errorf("expected the deployments to be filtered by project and tag, got %s", r.URL.RawQuery)
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": found, "numberOfElements": len(found), "totalElements": totalElements})
+	})
+	api.handle(http.MethodGet, "/deployment/api/deployments/"+deploymentID, func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": deploymentID, "name": "catalog-request", "projectId": "project-1"})
+	})
+
+	config := map[string]interface{}{
+		"project_id": "project-1",
+		"tags":       []interface{}{"env:test"},
+	}
+
+	if err := dataSourceDeploymentRead(dataSourceDeployment().TestResourceData(), c); err == nil {
+		t.Errorf("expected an error without id, name, project_id or tags")
+	}
+
+	if err := dataSourceDeploymentRead(schema.TestResourceDataRaw(t, dataSourceDeployment().Schema, config), c); err == nil ||
+		err.Error() != "vra_deployment did not match any deployments" {
+		t.Errorf("expected no deployment to match, got %v", err)
+	}
+
+	found = []interface{}{
+		map[string]interface{}{"id": deploymentID, "name": "catalog-request"},
+		map[string]interface{}{"id": "a0d0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6", "name": "other"},
+	}
+	if err := dataSourceDeploymentRead(schema.TestResourceDataRaw(t, dataSourceDeployment().Schema, config), c); err == nil ||
+		err.Error() != "vra_deployment must match a single deployment, 2 deployments found" {
+		t.Errorf("expected more than one deployment to match, got %v", err)
+	}
+
+	// Only the first page of the matching deployments is returned
+	found, totalElements = found[:1], 25
+	if err := dataSourceDeploymentRead(schema.TestResourceDataRaw(t, dataSourceDeployment().Schema, config), c); err == nil ||
+		err.Error() != "vra_deployment must match a single deployment, 25 deployments found" {
+		t.Errorf("expected the total number of matching deployments to be reported, got %v", err)
+	}
+
+	totalElements = 1
+	d := schema.TestResourceDataRaw(t, dataSourceDeployment().Schema, config)
+	if err := dataSourceDeploymentRead(d, c); err != nil {
+		t.Fatalf("read returned error %s", err)
+	}
+	if d.Id() != deploymentID || d.Get("name").(string) != "catalog-request" {
+		t.Errorf("expected deployment %s named catalog-request, got %s named %v", deploymentID, d.Id(), d.Get("name"))
+	}
+}
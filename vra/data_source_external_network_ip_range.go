@@ -0,0 +1,188 @@
+package vra
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client/network_ip_range"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func dataSourceExternalNetworkIPRange() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceExternalNetworkIPRangeRead,
+
+		Schema: map[string]*schema.Schema{
+			"address_space_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Address space that the range belongs to.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date when the entity was created. The date is in ISO 8601 and UTC.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A human-friendly description.",
+			},
+			"dns_search_domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "DNS domain search, in order.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"dns_server_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "DNS IP addresses of the range.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DNS domain of the range.",
+			},
+			"end_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "End IP address of the range.",
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "External entity Id on the provider side.",
+			},
+			"filter": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id"},
+				Description:   "Search criteria to narrow down the external IP ranges, for example \"name eq 'infoblox-range'\".",
+			},
+			"gateway_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The gateway address of the range.",
+			},
+			"id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"filter"},
+				Description:   "The id of the external IP range.",
+			},
+			"ip_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address version: IPv4 or IPv6.",
+			},
+			"links": linksSchema(),
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A human-friendly name used as an identifier in APIs that support this option.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the organization this entity belongs to.",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Email of the user that owns the entity.",
+			},
+			"start_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start IP address of the range.",
+			},
+			"subnet_prefix_length": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Subnet prefix length, synonymous with netmask.",
+			},
+			"tags": tagsSchema(),
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date when the entity was last updated. The date is ISO 8601 and UTC.",
+			},
+		},
+	}
+}
+
+func dataSourceExternalNetworkIPRangeRead(d *schema.ResourceData, meta interface{}) error {
+	apiClient := meta.(*Client).apiClient
+
+	id, idOk := d.GetOk("id")
+	filter, filterOk := d.GetOk("filter")
+
+	if !idOk && !filterOk {
+		return fmt.Errorf("one of id or filter must be assigned")
+	}
+
+	setFields := func(ipRange *models.ExternalNetworkIPRange) error {
+		d.SetId(*ipRange.ID)
+		d.Set("address_space_id", ipRange.AddressSpaceID)
+		d.Set("created_at", ipRange.CreatedAt)
+		d.Set("description", ipRange.Description)
+		d.Set("dns_search_domains", ipRange.DNSSearchDomains)
+		d.Set("dns_server_addresses", ipRange.DNSServerAddresses)
+		d.Set("domain", ipRange.Domain)
+		d.Set("end_ip_address", ipRange.EndIPAddress)
+		d.Set("external_id", ipRange.ExternalID)
+		d.Set("gateway_address", ipRange.GatewayAddress)
+		d.Set("ip_version", ipRange.IPVersion)
+		d.Set("name", ipRange.Name)
+		d.Set("org_id", ipRange.OrgID)
+		d.Set("owner", ipRange.Owner)
+		d.Set("start_ip_address", ipRange.StartIPAddress)
+		d.Set("subnet_prefix_length", ipRange.SubnetPrefixLength)
+		d.Set("updated_at", ipRange.UpdatedAt)
+
+		if err := d.Set("links", flattenLinks(filterLinks(ipRange.Links, meta.(*Client).linksFilter))); err != nil {
+			return fmt.Errorf("error setting external network ip range links - error: %#v", err)
+		}
+
+		if err := d.Set("tags", flattenTags(ipRange.Tags)); err != nil {
+			return fmt.Errorf("error setting external network ip range tags - error: %#v", err)
+		}
+
+		return nil
+	}
+
+	if idOk {
+		getResp, err := apiClient.NetworkIPRange.GetExternalNetworkIPRange(network_ip_range.NewGetExternalNetworkIPRangeParams().WithID(id.(string)))
+		if err != nil {
+			switch err.(type) {
+			case *network_ip_range.GetExternalNetworkIPRangeNotFound:
+				return fmt.Errorf("external network ip range %s not found", id.(string))
+			default:
+				return err
+			}
+		}
+
+		return setFields(getResp.Payload)
+	}
+
+	getResp, err := apiClient.NetworkIPRange.GetExternalNetworkIPRanges(network_ip_range.NewGetExternalNetworkIPRangesParams(), withFilter(filter.(string)))
+	if err != nil {
+		return err
+	}
+
+	switch ipRanges := getResp.Payload.Content; len(ipRanges) {
+	case 0:
+		return fmt.Errorf("vra_external_network_ip_range filter did not match any external network ip ranges")
+	case 1:
+		return setFields(ipRanges[0])
+	default:
+		return fmt.Errorf("vra_external_network_ip_range filter must match a single external network ip range, %d found", len(ipRanges))
+	}
+}
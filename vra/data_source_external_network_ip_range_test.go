@@ -0,0 +1,75 @@
+package vra
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDataSourceExternalNetworkIPRangeRead(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	ds := dataSourceExternalNetworkIPRange()
+
+	ipRanges := map[string]interface{}{
+		"range-1": map[string]interface{}{"id": "range-1", "name": "infoblox-range", "startIPAddress": "10.0.0.10",
+			"endIPAddress": "10.0.0.200", "subnetPrefixLength": 24, "gatewayAddress": "10.0.0.1",
+			"dnsServerAddresses": []interface{}{"10.0.0.2", "10.0.0.3"}, "_links": map[string]interface{}{}},
+		"range-2": map[string]interface{}{"id": "range-2", "name": "infoblox-range-2", "startIPAddress": "10.0.1.10",
+			"endIPAddress": "10.0.1.200", "subnetPrefixLength": 24, "_links": map[string]interface{}{}},
+	}
+	api.handle(http.MethodGet, "/iaas/api/external-network-ip-ranges", func(w http.ResponseWriter, r *http.Request) {
+		content := make([]interface{}, 0)
+		switch r.URL.Query().Get("$filter") {
+		case "name eq 'infoblox-range'":
+			content = append(content, ipRanges["range-1"])
+		case "startswith(name, 'infoblox')":
+			content = append(content, ipRanges["range-1"], ipRanges["range-2"])
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": content, "totalElements": len(content)})
+	})
+	api.handle(http.MethodGet, "/iaas/api/external-network-ip-ranges/", func(w http.ResponseWriter, r *http.Request) {
+		ipRange, ok := ipRanges[strings.TrimPrefix(r.URL.Path, "/iaas/api/external-network-ip-ranges/")]
+		if !ok {
+			api.writeError(w, http.StatusNotFound, "external network ip range not found")
+			return
+		}
+		api.writeJSON(w, http.StatusOK, ipRange)
+	})
+
+	var tests = []struct {
+		arg         string
+		value       string
+		expectedID  string
+		expectedErr string
+	}{
+		{"id", "range-1", "range-1", ""},
+		{"filter", "name eq 'infoblox-range'", "range-1", ""},
+		{"id", "range-3", "", "external network ip range range-3 not found"},
+		{"filter", "name eq 'missing'", "", "vra_external_network_ip_range filter did not match any external network ip ranges"},
+		{"filter", "startswith(name, 'infoblox')", "", "vra_external_network_ip_range filter must match a single external network ip range, 2 found"},
+	}
+
+	for _, tt := range tests {
+		d := ds.TestResourceData()
+		d.Set(tt.arg, tt.value)
+
+		err := ds.Read(d, c)
+		if tt.expectedErr != "" {
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("%s %q: expected error %q, got %v", tt.arg, tt.value, tt.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s %q: read returned error %s", tt.arg, tt.value, err)
+		}
+		if d.Id() != tt.expectedID {
+			t.Errorf("%s %q: expected id %q, got %q", tt.arg, tt.value, tt.expectedID, d.Id())
+		}
+		if d.Get("gateway_address").(string) != "10.0.0.1" || d.Get("subnet_prefix_length").(int) != 24 || d.Get("dns_server_addresses.#").(int) != 2 {
+			t.Errorf("%s %q: unexpected range attributes gateway_address %v, subnet_prefix_length %v, dns_server_addresses %v",
+				tt.arg, tt.value, d.Get("gateway_address"), d.Get("subnet_prefix_length"), d.Get("dns_server_addresses"))
+		}
+	}
+}
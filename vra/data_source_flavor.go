@@ -0,0 +1,96 @@
+package vra
+
+import (
+	"fmt"
+
+	"github.com/vmware/vra-sdk-go/pkg/client/fabric_flavors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFlavor() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceFlavorRead,
+
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Search criteria to narrow down the fabric flavors, for example \"externalRegionId eq 'us-east-1' and name eq 't2.micro'\".",
+			},
+			"boot_disk_size_in_mb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the boot disk in megabytes. Not populated when inapplicable.",
+			},
+			"cpu_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of CPU cores. Not populated when inapplicable.",
+			},
+			"data_disk_max_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of data disks. Not populated when inapplicable.",
+			},
+			"data_disk_size_in_mb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the data disks in megabytes. Not populated when inapplicable.",
+			},
+			"memory_in_mb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total amount of memory in megabytes. Not populated when inapplicable.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The value of the instance type in the corresponding cloud.",
+			},
+			"network_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of network supported by this instance type. Not populated when inapplicable.",
+			},
+			"storage_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of storage supported by this instance type. Not populated when inapplicable.",
+			},
+		},
+	}
+}
+
+func dataSourceFlavorRead(d *schema.ResourceData, meta interface{}) error {
+	apiClient := meta.(*Client).apiClient
+
+	filter := d.Get("filter").(string)
+
+	getResp, err := apiClient.FabricFlavors.GetFabricFlavors(fabric_flavors.NewGetFabricFlavorsParams().WithDollarFilter(withString(filter)))
+	if err != nil {
+		return err
+	}
+
+	flavors := getResp.Payload
+	if len(flavors.Content) > 1 {
+		return fmt.Errorf("vra_flavor must filter to a single flavor, %d flavors found", len(flavors.Content))
+	}
+	if len(flavors.Content) == 0 {
+		return fmt.Errorf("vra_flavor filter did not match any flavors")
+	}
+
+	flavor := flavors.Content[0]
+	d.Set("boot_disk_size_in_mb", flavor.BootDiskSizeInMB)
+	d.Set("cpu_count", flavor.CPUCount)
+	d.Set("data_disk_max_count", flavor.DataDiskMaxCount)
+	d.Set("data_disk_size_in_mb", flavor.DataDiskSizeInMB)
+	d.Set("memory_in_mb", flavor.MemoryInMB)
+	d.Set("name", flavor.Name)
+	d.Set("network_type", flavor.NetworkType)
+	d.Set("storage_type", flavor.StorageType)
+
+	d.SetId(flavor.ID)
+
+	return nil
+}
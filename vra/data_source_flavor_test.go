@@ -0,0 +1,59 @@
+package vra
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDataSourceFlavorRead(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	ds := dataSourceFlavor()
+
+	flavors := []interface{}{
+		map[string]interface{}{"id": "t2.micro", "name": "t2.micro", "cpuCount": 1, "memoryInMB": 1024, "networkType": "Low to Moderate"},
+		map[string]interface{}{"id": "t2.small", "name": "t2.small", "cpuCount": 1, "memoryInMB": 2048, "networkType": "Low to Moderate"},
+	}
+	api.handle(http.MethodGet, "/iaas/api/fabric-flavors", func(w http.ResponseWriter, r *http.Request) {
+		content := make([]interface{}, 0)
+		switch r.URL.Query().Get("$filter") {
+		case "externalRegionId eq 'us-east-1' and name eq 't2.micro'":
+			content = append(content, flavors[0])
+		case "externalRegionId eq 'us-east-1'":
+			content = append(content, flavors...)
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": content, "totalElements": len(content)})
+	})
+
+	var tests = []struct {
+		filter      string
+		expectedID  string
+		expectedErr string
+	}{
+		{"externalRegionId eq 'us-east-1' and name eq 't2.micro'", "t2.micro", ""},
+		{"externalRegionId eq 'us-west-2'", "", "vra_flavor filter did not match any flavors"},
+		{"externalRegionId eq 'us-east-1'", "", "vra_flavor must filter to a single flavor, 2 flavors found"},
+	}
+
+	for _, tt := range tests {
+		d := ds.TestResourceData()
+		d.Set("filter", tt.filter)
+
+		err := ds.Read(d, c)
+		if tt.expectedErr != "" {
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("filter %q: expected error %q, got %v", tt.filter, tt.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("filter %q: read returned error %s", tt.filter, err)
+		}
+		if d.Id() != tt.expectedID {
+			t.Errorf("filter %q: expected id %q, got %q", tt.filter, tt.expectedID, d.Id())
+		}
+		if d.Get("cpu_count").(int) != 1 || d.Get("memory_in_mb").(int) != 1024 || d.Get("network_type").(string) != "Low to Moderate" {
+			t.Errorf("filter %q: unexpected flavor attributes cpu_count %v, memory_in_mb %v, network_type %v", tt.filter, d.Get("cpu_count"), d.Get("memory_in_mb"), d.Get("network_type"))
+		}
+	}
+}
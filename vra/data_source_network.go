@@ -14,15 +14,23 @@ func dataSourceNetwork() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceNetworkRead,
 		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"id", "name"},
+				Description:   "Search criteria to narrow down the networks, for example \"tags.item.key eq 'env' and tags.item.value eq 'dev'\".",
+			},
 			"id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"filter"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"filter"},
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					return !strings.HasPrefix(new, old)
 				},
@@ -87,17 +95,22 @@ func dataSourceNetworkRead(d *schema.ResourceData, meta interface{}) error {
 
 	id, idOk := d.GetOk("id")
 	name, nameOk := d.GetOk("name")
+	filter, filterOk := d.GetOk("filter")
 
-	if !idOk && !nameOk {
-		return fmt.Errorf("One of id or name must be assigned")
+	if !idOk && !nameOk && !filterOk {
+		return fmt.Errorf("one of id, name or filter must be assigned")
 	}
 
-	getResp, err := apiClient.Network.GetNetworks(network.NewGetNetworksParams())
+	var opts []network.ClientOption
+	if filterOk {
+		opts = append(opts, withFilter(filter.(string)))
+	}
+	getResp, err := apiClient.Network.GetNetworks(network.NewGetNetworksParams(), opts...)
 	if err != nil {
 		return err
 	}
 
-	setFields := func(network *models.Network) {
+	setFields := func(network *models.Network) error {
 		d.SetId(*network.ID)
 		d.Set("cidr", network.Cidr)
 		d.Set("custom_properties", network.CustomProperties)
@@ -109,19 +122,37 @@ func dataSourceNetworkRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("organization_id", network.OrganizationID)
 		d.Set("owner", network.Owner)
 		d.Set("project_id", network.ProjectID)
-		d.Set("tags", network.Tags)
 		d.Set("updated_at", network.UpdatedAt)
+
+		if err := d.Set("tags", flattenTags(network.Tags)); err != nil {
+			return fmt.Errorf("error setting network tags - error: %v", err)
+		}
+
+		return nil
 	}
+
+	if filterOk {
+		switch networks := getResp.Payload.Content; len(networks) {
+		case 0:
+			return fmt.Errorf("vra_network filter did not match any networks")
+		case 1:
+			return setFields(networks[0])
+		default:
+			return fmt.Errorf("vra_network filter must match a single network, %d networks found", len(networks))
+		}
+	}
+
 	for _, network := range getResp.Payload.Content {
-		if idOk && network.ID == id {
-			setFields(network)
-			return nil
+		if idOk && network.ID != nil && *network.ID == id.(string) {
+			return setFields(network)
 		}
-		if nameOk && network.Name == name {
-			setFields(network)
-			return nil
+		if nameOk && network.Name == name.(string) {
+			return setFields(network)
 		}
 	}
 
+	if idOk {
+		return fmt.Errorf("network %s not found", id)
+	}
 	return fmt.Errorf("network %s not found", name)
 }
@@ -1,6 +1,7 @@
 package vra
 
 import (
+	"net/http"
 	"regexp"
 	"testing"
 
@@ -43,3 +44,64 @@ func testAccDataSourceVRANetworkOneConfig(rInt int) string {
 			name = "foo1-mcm653-56201379059"
 		}`
 }
+
+func TestDataSourceNetworkRead(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	ds := dataSourceNetwork()
+
+	networks := []interface{}{
+		map[string]interface{}{"id": "network-1", "name": "dev-net", "cidr": "10.0.0.0/24", "externalId": "network-a",
+			"tags": []interface{}{map[string]interface{}{"key": "env", "value": "dev"}}},
+		map[string]interface{}{"id": "network-2", "name": "prod-net", "cidr": "10.1.0.0/24", "externalId": "network-b",
+			"tags": []interface{}{map[string]interface{}{"key": "env", "value": "prod"}}},
+	}
+	api.handle(http.MethodGet, "/iaas/api/networks", func(w http.ResponseWriter, r *http.Request) {
+		content := make([]interface{}, 0)
+		switch r.URL.Query().Get("$filter") {
+		case "":
+			content = append(content, networks...)
+		case "tags.item.value eq 'dev'":
+			content = append(content, networks[0])
+		case "cidr ne ''":
+			content = append(content, networks...)
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": content, "totalElements": len(content)})
+	})
+
+	var tests = []struct {
+		arg         string
+		value       string
+		expectedID  string
+		expectedErr string
+	}{
+		{"id", "network-2", "network-2", ""},
+		{"name", "dev-net", "network-1", ""},
+		{"filter", "tags.item.value eq 'dev'", "network-1", ""},
+		{"id", "network-3", "", "network network-3 not found"},
+		{"filter", "name eq 'missing'", "", "vra_network filter did not match any networks"},
+		{"filter", "cidr ne ''", "", "vra_network filter must match a single network, 2 networks found"},
+	}
+
+	for _, tt := range tests {
+		d := ds.TestResourceData()
+		d.Set(tt.arg, tt.value)
+
+		err := ds.Read(d, c)
+		if tt.expectedErr != "" {
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("%s %q: expected error %q, got %v", tt.arg, tt.value, tt.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s %q: read returned error %s", tt.arg, tt.value, err)
+		}
+		if d.Id() != tt.expectedID {
+			t.Errorf("%s %q: expected id %q, got %q", tt.arg, tt.value, tt.expectedID, d.Id())
+		}
+		if d.Get("tags.#").(int) != 1 {
+			t.Errorf("%s %q: expected 1 tag, got %v", tt.arg, tt.value, d.Get("tags"))
+		}
+	}
+}
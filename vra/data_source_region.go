@@ -48,6 +48,7 @@ func dataSourceRegion() *schema.Resource {
 				ConflictsWith: []string{"cloud_account_id", "filter", "region"},
 				Description:   "The id of the region instance.",
 			},
+			"links": linksSchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -96,6 +97,7 @@ func dataSourceRegionRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("cloud_account_id", region.CloudAccountID)
 		d.Set("created_at", region.CreatedAt)
 		d.Set("external_region_id", region.ExternalRegionID)
+		d.Set("links", flattenLinks(filterLinks(region.Links, meta.(*Client).linksFilter)))
 		d.Set("name", region.Name)
 		d.Set("org_id", region.OrgID)
 		d.Set("owner", region.Owner)
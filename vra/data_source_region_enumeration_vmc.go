@@ -57,7 +57,7 @@ func dataSourceRegionEnumerationVMCRead(d *schema.ResourceData, meta interface{}
 
 	getResp, err := apiClient.CloudAccount.EnumerateVmcRegions(
 		cloud_account.NewEnumerateVmcRegionsParams().
-			WithTimeout(IncreasedTimeOut).
+			WithTimeout(meta.(*Client).apiTimeout).
 			WithBody(&models.CloudAccountVmcSpecification{
 				AcceptSelfSignedCertificate: d.Get("accept_self_signed_cert").(bool),
 				APIKey:                      d.Get("api_token").(string),
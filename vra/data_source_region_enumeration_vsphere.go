@@ -49,7 +49,7 @@ func dataSourceRegionEnumerationVsphereRead(d *schema.ResourceData, meta interfa
 
 	getResp, err := apiClient.CloudAccount.EnumerateVSphereRegions(
 		cloud_account.NewEnumerateVSphereRegionsParams().
-			WithTimeout(IncreasedTimeOut).
+			WithTimeout(meta.(*Client).apiTimeout).
 			WithBody(&models.CloudAccountVsphereSpecification{
 				AcceptSelfSignedCertificate: d.Get("accept_self_signed_cert").(bool),
 				Dcid:                        d.Get("dcid").(string),
@@ -28,6 +28,7 @@ func TestAccDataSourceVRARegionBasic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(resourceName, "id", dataSourceName, "cloud_account_id"),
 					resource.TestCheckResourceAttrPair(resourceName, "region_ids.0", dataSourceName, "id"),
 					resource.TestCheckResourceAttr(dataSourceName, "region", "us-east-1"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "links.#"),
 				),
 			},
 		},
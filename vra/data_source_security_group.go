@@ -85,7 +85,7 @@ func dataSourceSecurityGroupRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("name", securityGroup.Name)
 	d.Set("organization_id", securityGroup.OrganizationID)
 	d.Set("owner", securityGroup.Owner)
-	d.Set("rules", securityGroup.Rules)
+	d.Set("rules", flattenRules(securityGroup.Rules))
 	d.Set("updated_at", securityGroup.UpdatedAt)
 
 	return nil
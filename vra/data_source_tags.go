@@ -0,0 +1,129 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client/tags"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// tagsPageSize is the number of tags requested per page
+const tagsPageSize = 100
+
+func dataSourceTags() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTagsRead,
+
+		Schema: map[string]*schema.Schema{
+			"key_values": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The distinct tag keys with the distinct values used with each key, sorted by key.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The distinct tag keys, sorted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTagsRead(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("Reading the vra_tags data source")
+	apiClient := meta.(*Client).apiClient
+
+	allTags := make([]*models.Tag, 0)
+	for {
+		getResp, err := apiClient.Tags.GetTags(tags.NewGetTagsParams(), withTagsPage(len(allTags), tagsPageSize))
+		if err != nil {
+			return err
+		}
+
+		page := getResp.GetPayload().Content
+		allTags = append(allTags, page...)
+
+		if len(page) == 0 || int64(len(allTags)) >= getResp.GetPayload().TotalElements {
+			break
+		}
+	}
+
+	keys, keyValues := flattenTagKeyValues(allTags)
+
+	d.SetId(meta.(*Client).url)
+	if err := d.Set("keys", keys); err != nil {
+		return fmt.Errorf("error setting tag keys - error: %#v", err)
+	}
+	if err := d.Set("key_values", keyValues); err != nil {
+		return fmt.Errorf("error setting tag key_values - error: %#v", err)
+	}
+
+	log.Printf("Finished reading the vra_tags data source, found %d tags", len(allTags))
+	return nil
+}
+
+// withTagsPage adds the paging query parameters to the tags request, the generated parameters do not expose them
+func withTagsPage(skip, top int) tags.ClientOption {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+			if err := r.SetQueryParam("$skip", strconv.Itoa(skip)); err != nil {
+				return err
+			}
+			return r.SetQueryParam("$top", strconv.Itoa(top))
+		})
+	}
+}
+
+// flattenTagKeyValues returns the distinct keys of the tags, and the distinct values of each key, sorted
+func flattenTagKeyValues(tagList []*models.Tag) ([]string, []map[string]interface{}) {
+	values := make(map[string]map[string]bool)
+	for _, tag := range tagList {
+		if tag == nil || tag.Key == nil {
+			continue
+		}
+		if _, ok := values[*tag.Key]; !ok {
+			values[*tag.Key] = make(map[string]bool)
+		}
+		if tag.Value != nil && *tag.Value != "" {
+			values[*tag.Key][*tag.Value] = true
+		}
+	}
+
+	keys := sortedKeys(values)
+	keyValues := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		keyValues = append(keyValues, map[string]interface{}{
+			"key":    key,
+			"values": sortedKeys(values[key]),
+		})
+	}
+
+	return keys, keyValues
+}
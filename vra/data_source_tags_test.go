@@ -0,0 +1,53 @@
+package vra
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestFlattenTagKeyValues(t *testing.T) {
+	tagList := []*models.Tag{
+		{Key: withString("env"), Value: withString("prod")},
+		{Key: withString("region"), Value: withString("emea")},
+		{Key: withString("env"), Value: withString("dev")},
+		{Key: withString("env"), Value: withString("prod")},
+		{Key: withString("standalone")},
+		nil,
+	}
+
+	keys, keyValues := flattenTagKeyValues(tagList)
+
+	if !reflect.DeepEqual(keys, []string{"env", "region", "standalone"}) {
+		t.Errorf("tag keys are not flattened correctly: %#v", keys)
+	}
+
+	expected := []map[string]interface{}{
+		{"key": "env", "values": []string{"dev", "prod"}},
+		{"key": "region", "values": []string{"emea"}},
+		{"key": "standalone", "values": []string{}},
+	}
+	if !reflect.DeepEqual(keyValues, expected) {
+		t.Errorf("tag key values are not flattened correctly: %#v", keyValues)
+	}
+}
+
+func TestAccDataSourceVRATags(t *testing.T) {
+	dataSourceName := "data.vra_tags.this"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "vra_tags" "this" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "keys.#"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "key_values.#"),
+				),
+			},
+		},
+	})
+}
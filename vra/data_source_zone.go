@@ -3,6 +3,7 @@ package vra
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vra-sdk-go/pkg/client/location"
@@ -14,20 +15,33 @@ func dataSourceZone() *schema.Resource {
 		Read: dataSourceZoneRead,
 
 		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:          schema.TypeString,
+				ConflictsWith: []string{"id", "name", "region_id"},
+				Description:   "Search criteria to narrow down the zones. The filter must match a single zone.",
+				Optional:      true,
+			},
 			"id": {
 				Type:          schema.TypeString,
 				Computed:      true,
-				ConflictsWith: []string{"name"},
+				ConflictsWith: []string{"filter", "name"},
 				Description:   "The id of the zone resource instance.",
 				Optional:      true,
 			},
 			"name": {
 				Type:          schema.TypeString,
 				Computed:      true,
-				ConflictsWith: []string{"id"},
+				ConflictsWith: []string{"filter", "id"},
 				Description:   "A human-friendly name used as an identifier for the zone resource instance.",
 				Optional:      true,
 			},
+			"region_id": {
+				Type:          schema.TypeString,
+				Computed:      true,
+				ConflictsWith: []string{"filter", "id"},
+				Description:   "The id of the region for which this zone is defined. Used to narrow down the search by name.",
+				Optional:      true,
+			},
 			"cloud_account_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -98,12 +112,18 @@ func dataSourceZoneRead(d *schema.ResourceData, m interface{}) error {
 
 	id, idOk := d.GetOk("id")
 	name, nameOk := d.GetOk("name")
+	regionID, regionIDOk := d.GetOk("region_id")
+	filter, filterOk := d.GetOk("filter")
 
-	if !idOk && !nameOk {
-		return errors.New("one of id or name must be assigned")
+	if !idOk && !nameOk && !filterOk {
+		return errors.New("one of id, name or filter must be assigned")
 	}
 
-	getResp, err := apiClient.Location.GetZones(location.NewGetZonesParams())
+	var opts []location.ClientOption
+	if filterOk {
+		opts = append(opts, withFilter(filter.(string)))
+	}
+	getResp, err := apiClient.Location.GetZones(location.NewGetZonesParams(), opts...)
 	if err != nil {
 		return err
 	}
@@ -120,6 +140,7 @@ func dataSourceZoneRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("org_id", zone.OrgID)
 		d.Set("owner", zone.Owner)
 		d.Set("placement_policy", zone.PlacementPolicy)
+		d.Set("region_id", zoneRegionID(zone))
 		d.Set("updated_at", zone.UpdatedAt)
 
 		if err := d.Set("links", flattenLinks(zone.Links)); err != nil {
@@ -148,14 +169,39 @@ func dataSourceZoneRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
+	if filterOk {
+		switch zones := getResp.Payload.Content; len(zones) {
+		case 0:
+			return errors.New("vra_zone filter did not match any zones")
+		case 1:
+			return setFields(zones[0])
+		default:
+			return fmt.Errorf("vra_zone filter must match a single zone, %d zones found", len(zones))
+		}
+	}
+
+	var matches []*models.Zone
 	for _, zone := range getResp.Payload.Content {
 		if idOk && *zone.ID == id {
 			return setFields(zone)
 		}
-		if nameOk && zone.Name == name {
-			return setFields(zone)
+		if nameOk && zone.Name == name && (!regionIDOk || zoneRegionID(zone) == regionID) {
+			matches = append(matches, zone)
 		}
 	}
 
+	if len(matches) > 1 {
+		return fmt.Errorf("%d zones found with name `%s`, narrow the search down with region_id", len(matches), name)
+	}
+
+	if len(matches) == 1 {
+		return setFields(matches[0])
+	}
+
 	return fmt.Errorf("zone with id `%s` or name `%s` not found", id, name)
 }
+
+// zoneRegionID returns the id of the region of the zone from its links
+func zoneRegionID(zone *models.Zone) string {
+	return strings.TrimPrefix(zone.Links["region"].Href, "/iaas/api/regions/")
+}
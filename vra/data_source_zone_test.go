@@ -2,6 +2,7 @@ package vra
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"testing"
@@ -35,6 +36,15 @@ func TestAccDataSourceVRAZoneBasic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(resourceName1, "name", dataSourceName1, "name"),
 				),
 			},
+			{
+				Config: testAccDataSourceVRAZoneRegionConfig(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName1, "id", dataSourceName1, "id"),
+					resource.TestCheckResourceAttrPair(resourceName1, "region_id", dataSourceName1, "region_id"),
+					resource.TestCheckResourceAttrPair(resourceName1, "placement_policy", dataSourceName1, "placement_policy"),
+					resource.TestCheckResourceAttr(dataSourceName1, "tags_to_match.#", "0"),
+				),
+			},
 		},
 	})
 }
@@ -85,6 +95,14 @@ func testAccDataSourceVRAZoneOneConfig(rInt int) string {
 		}`
 }
 
+func testAccDataSourceVRAZoneRegionConfig(rInt int) string {
+	return testAccDataSourceVRAZone(rInt) + `
+		data "vra_zone" "test-zone" {
+			name      = "${vra_zone.my-zone.name}"
+			region_id = "${vra_zone.my-zone.region_id}"
+		}`
+}
+
 func testAccCheckVRADataSourceZoneDestroy(s *terraform.State) error {
 	apiClient := testAccProviderVRA.Meta().(*Client).apiClient
 
@@ -105,3 +123,56 @@ func testAccCheckVRADataSourceZoneDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestDataSourceZoneRead_filter(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	ds := dataSourceZone()
+
+	zones := []interface{}{
+		map[string]interface{}{"id": "zone-1", "name": "vsphere-zone", "placementPolicy": "SPREAD"},
+		map[string]interface{}{"id": "zone-2", "name": "aws-zone", "placementPolicy": "DEFAULT"},
+	}
+	api.handle(http.MethodGet, "/iaas/api/zones", func(w http.ResponseWriter, r *http.Request) {
+		content := make([]interface{}, 0)
+		switch r.URL.Query().Get("$filter") {
+		case "name eq 'vsphere-zone'":
+			content = append(content, zones[0])
+		case "name ne 'none'":
+			content = append(content, zones...)
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": content, "totalElements": len(content)})
+	})
+	api.handle(http.MethodGet, "/iaas/api/zones/", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": []interface{}{}, "totalElements": 0})
+	})
+
+	var tests = []struct {
+		filter      string
+		expectedID  string
+		expectedErr string
+	}{
+		{"name eq 'vsphere-zone'", "zone-1", ""},
+		{"name eq 'missing'", "", "vra_zone filter did not match any zones"},
+		{"name ne 'none'", "", "vra_zone filter must match a single zone, 2 zones found"},
+	}
+
+	for _, tt := range tests {
+		d := ds.TestResourceData()
+		d.Set("filter", tt.filter)
+
+		err := ds.Read(d, c)
+		if tt.expectedErr != "" {
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("filter %q: expected error %q, got %v", tt.filter, tt.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("filter %q: read returned error %s", tt.filter, err)
+		}
+		if d.Id() != tt.expectedID || d.Get("placement_policy") != "SPREAD" {
+			t.Errorf("filter %q: expected zone %s with placement policy SPREAD, got %s with %s", tt.filter, tt.expectedID, d.Id(), d.Get("placement_policy"))
+		}
+	}
+}
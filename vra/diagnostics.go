@@ -0,0 +1,243 @@
+package vra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// redactedSecret replaces the secrets redacted from error messages
+const redactedSecret = "********"
+
+// cloudAccountVsphereAPIFields maps the API field names of a vSphere cloud account specification to schema attributes
+var cloudAccountVsphereAPIFields = map[string]string{
+	"acceptSelfSignedCertificate": "accept_self_signed_cert",
+	"associatedCloudAccountIds":   "associated_cloud_account_ids",
+	"dcid":                        "dcid",
+	"description":                 "description",
+	"hostName":                    "hostname",
+	"name":                        "name",
+	"password":                    "password",
+	"regionIds":                   "regions",
+	"tags":                        "tags",
+	"username":                    "username",
+}
+
+// diagFromAPIError converts an error returned by the SDK into diagnostics. When the error carries a payload with
+// per-field messages, a diagnostic is emitted for each field with its AttributePath set to the matching schema
+// attribute. When the payload only carries a message, a single diagnostic is emitted. Any other error is converted
+// as is.
+func diagFromAPIError(err error, fields map[string]string) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	payload := apiErrorPayload(err)
+	if payload == nil {
+		return diag.FromErr(err)
+	}
+
+	message, _ := payload["message"].(string)
+	if message == "" {
+		message = err.Error()
+	}
+
+	var diags diag.Diagnostics
+
+	// Some validation responses carry a map of field name to message
+	for _, value := range payload {
+		fieldErrors, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range sortedKeys(fieldErrors) {
+			attribute, ok := fields[field]
+			if !ok {
+				continue
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("invalid value for %s", attribute),
+				Detail:        fmt.Sprint(fieldErrors[field]),
+				AttributePath: cty.GetAttrPath(attribute),
+			})
+		}
+	}
+
+	if len(diags) != 0 {
+		return diags
+	}
+
+	// Otherwise, attribute the message to the field it mentions by name. Only a quoted field name, or one followed by a
+	// colon, is taken as a mention, since field names such as name are also common words. A message that mentions
+	// several fields, or none, is not attributed to any of them. The original error is kept in the detail, since it
+	// names the request and the status code of the response.
+	quotedFields := make([]string, 0, len(fields))
+	for _, field := range sortedKeys(fields) {
+		quotedFields = append(quotedFields, regexp.QuoteMeta(field))
+	}
+	mentioned := make(map[string]bool)
+	if len(quotedFields) != 0 {
+		names := `(` + strings.Join(quotedFields, "|") + `)`
+		fieldPattern := regexp.MustCompile(`['"\x60]` + names + `['"\x60]|\b` + names + `:`)
+		for _, match := range fieldPattern.FindAllStringSubmatch(message, -1) {
+			if match[1] != "" {
+				mentioned[match[1]] = true
+			} else {
+				mentioned[match[2]] = true
+			}
+		}
+	}
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  message,
+		Detail:   err.Error(),
+	}
+	if len(mentioned) == 1 {
+		for field := range mentioned {
+			diagnostic.AttributePath = cty.GetAttrPath(fields[field])
+		}
+	}
+
+	return diag.Diagnostics{diagnostic}
+}
+
+// cloudAccountValidationCauses classifies the message of a failed cloud account validation by its cause, in order. A
+// certificate error is checked first, since its message usually also mentions the failed connection.
+var cloudAccountValidationCauses = []struct {
+	pattern   *regexp.Regexp
+	summary   string
+	attribute string
+}{
+	{regexp.MustCompile(`(?i)certificate|\bssl\b|\bpkix\b|x509`), "the certificate of the endpoint is not trusted", "accept_self_signed_cert"},
+	{regexp.MustCompile(`(?i)credential|password|user ?name|log ?in|authenticat|unauthori[sz]ed|permission`), "the credentials were rejected", "username"},
+	{regexp.MustCompile(`(?i)unknown ?host|unreachable|connect|timed? ?out|resolve|no route`), "the endpoint is unreachable", "hostname"},
+}
+
+// diagFromCloudAccountValidationError converts the error of a cloud account validation into diagnostics like
+// diagFromAPIError. When the error is a single message, its summary also tells whether the credentials were rejected,
+// the endpoint is unreachable or its certificate is not trusted, and it is attributed to the matching attribute unless
+// the message names another one.
+func diagFromCloudAccountValidationError(err error, fields map[string]string) diag.Diagnostics {
+	diags := diagFromAPIError(err, fields)
+	if len(diags) != 1 {
+		return diags
+	}
+
+	message := apiErrorMessage(err)
+	for _, cause := range cloudAccountValidationCauses {
+		if !cause.pattern.MatchString(message) {
+			continue
+		}
+		diags[0].Summary = fmt.Sprintf("cloud account validation failed, %s: %s", cause.summary, diags[0].Summary)
+		if diags[0].AttributePath == nil {
+			diags[0].AttributePath = cty.GetAttrPath(cause.attribute)
+		}
+		break
+	}
+
+	return diags
+}
+
+// isBadRequestError returns true if the API rejected the request as invalid. The operations that declare a 400
+// response return it as their own BadRequest type, the others as a generic runtime.APIError.
+func isBadRequestError(err error) bool {
+	if apiErr, ok := err.(*runtime.APIError); ok {
+		return apiErr.Code == http.StatusBadRequest
+	}
+	t := reflect.TypeOf(err)
+	return t != nil && t.Kind() == reflect.Ptr && strings.HasSuffix(t.Elem().Name(), "BadRequest")
+}
+
+// apiErrorMessage returns the message of an SDK error response, or the error itself if it has no message
+func apiErrorMessage(err error) string {
+	if message, _ := apiErrorPayload(err)["message"].(string); message != "" {
+		return message
+	}
+	return err.Error()
+}
+
+// apiErrorPayload returns the payload of an SDK error response as a generic map, or nil if the error has none.
+// The generated error responses all expose GetPayload, but with different payload types, so it is called by reflection.
+func apiErrorPayload(err error) map[string]interface{} {
+	method := reflect.ValueOf(err).MethodByName("GetPayload")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	result := method.Call(nil)[0]
+	if (result.Kind() == reflect.Ptr || result.Kind() == reflect.Interface) && result.IsNil() {
+		return nil
+	}
+
+	b, jsonErr := json.Marshal(result.Interface())
+	if jsonErr != nil {
+		return nil
+	}
+
+	payload := make(map[string]interface{})
+	if jsonErr := json.Unmarshal(b, &payload); jsonErr != nil {
+		return nil
+	}
+
+	return payload
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := make([]string, 0)
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// redactSecrets replaces every occurrence of the secrets in s. Empty secrets are ignored.
+func redactSecrets(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redactedSecret)
+		}
+	}
+	return s
+}
+
+// redactSecretsFromDiagnostics replaces every occurrence of the secrets in the summary and detail of the diagnostics
+func redactSecretsFromDiagnostics(diags diag.Diagnostics, secrets ...string) diag.Diagnostics {
+	for i := range diags {
+		diags[i].Summary = redactSecrets(diags[i].Summary, secrets...)
+		diags[i].Detail = redactSecrets(diags[i].Detail, secrets...)
+	}
+	return diags
+}
+
+// withSecretsRedacted wraps a create, read, update or delete function so that the values of the given sensitive
+// attributes are redacted from the diagnostics it returns. Some error responses echo back the request, including
+// the credentials.
+func withSecretsRedacted(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, keys ...string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := f(ctx, d, m)
+		if len(diags) == 0 {
+			return diags
+		}
+
+		secrets := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if v, ok := d.Get(key).(string); ok {
+				secrets = append(secrets, v)
+			}
+		}
+		return redactSecretsFromDiagnostics(diags, secrets...)
+	}
+}
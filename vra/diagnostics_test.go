@@ -0,0 +1,212 @@
+package vra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
+)
+
+type testAPIErrorPayload struct {
+	Message          string            `json:"message,omitempty"`
+	ValidationErrors map[string]string `json:"validationErrors,omitempty"`
+}
+
+type testAPIError struct {
+	Payload *testAPIErrorPayload
+}
+
+func (e *testAPIError) Error() string {
+	return "[POST /iaas/api/cloud-accounts-vsphere][400] createVSphereCloudAccountBadRequest"
+}
+
+func (e *testAPIError) GetPayload() *testAPIErrorPayload {
+	return e.Payload
+}
+
+func TestDiagFromAPIError(t *testing.T) {
+	if diags := diagFromAPIError(nil, cloudAccountVsphereAPIFields); diags != nil {
+		t.Errorf("expected no diagnostics for a nil error, got %#v", diags)
+	}
+
+	diags := diagFromAPIError(errors.New("plain error"), cloudAccountVsphereAPIFields)
+	if len(diags) != 1 || diags[0].Summary != "plain error" || diags[0].AttributePath != nil {
+		t.Errorf("plain error is not converted correctly: %#v", diags)
+	}
+
+	diags = diagFromAPIError(&testAPIError{}, cloudAccountVsphereAPIFields)
+	if len(diags) != 1 || diags[0].AttributePath != nil {
+		t.Errorf("error without payload is not converted correctly: %#v", diags)
+	}
+
+	diags = diagFromAPIError(&testAPIError{Payload: &testAPIErrorPayload{
+		Message: "Invalid request",
+		ValidationErrors: map[string]string{
+			"hostName":  "must not be blank",
+			"regionIds": "unknown region Datacenter:datacenter-99",
+			"unknown":   "ignored",
+		},
+	}}, cloudAccountVsphereAPIFields)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 field diagnostics, got %#v", diags)
+	}
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("hostname")) || diags[0].Detail != "must not be blank" {
+		t.Errorf("hostname diagnostic is not converted correctly: %#v", diags[0])
+	}
+	if !diags[1].AttributePath.Equals(cty.GetAttrPath("regions")) {
+		t.Errorf("regions diagnostic is not converted correctly: %#v", diags[1])
+	}
+
+	for _, message := range []string{"Cannot connect to 'hostName' vcenter.corp", "hostName: cannot connect to vcenter.corp"} {
+		diags = diagFromAPIError(&testAPIError{Payload: &testAPIErrorPayload{
+			Message: message,
+		}}, cloudAccountVsphereAPIFields)
+		if len(diags) != 1 || !diags[0].AttributePath.Equals(cty.GetAttrPath("hostname")) {
+			t.Errorf("message %q is not attributed to the mentioned field: %#v", message, diags)
+		}
+	}
+
+	diags = diagFromAPIError(&testAPIError{Payload: &testAPIErrorPayload{
+		Message: `Cannot connect to "hostName" with the given "username"`,
+	}}, cloudAccountVsphereAPIFields)
+	if len(diags) != 1 || diags[0].AttributePath != nil {
+		t.Errorf("message mentioning several fields must be a single diagnostic: %#v", diags)
+	}
+
+	diags = diagFromAPIError(&testAPIError{Payload: &testAPIErrorPayload{
+		Message: "The name of the vCenter host could not be resolved",
+	}}, cloudAccountVsphereAPIFields)
+	if len(diags) != 1 || diags[0].AttributePath != nil {
+		t.Errorf("field name used as a word must not be attributed: %#v", diags)
+	}
+
+	apiErr := &testAPIError{Payload: &testAPIErrorPayload{
+		Message: "Internal server error",
+	}}
+	diags = diagFromAPIError(apiErr, cloudAccountVsphereAPIFields)
+	if len(diags) != 1 || diags[0].Summary != "Internal server error" || diags[0].AttributePath != nil {
+		t.Errorf("message without fields is not converted correctly: %#v", diags)
+	}
+	if diags[0].Detail != apiErr.Error() {
+		t.Errorf("expected the original error %q in the detail, got %q", apiErr.Error(), diags[0].Detail)
+	}
+}
+
+func TestWithSecretsRedacted(t *testing.T) {
+	password := "VMware1!secret"
+	d := schema.TestResourceDataRaw(t, resourceCloudAccountVsphere().Schema, map[string]interface{}{
+		"password": password,
+	})
+
+	f := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Cannot connect with password %s", password),
+			Detail:   fmt.Sprintf(`{"hostName":"vc.example.com","password":"%s"}`, password),
+		}}
+	}
+
+	diags := withSecretsRedacted(f, "password")(context.Background(), d, nil)
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %#v", diags)
+	}
+	if strings.Contains(diags[0].Summary, password) || strings.Contains(diags[0].Detail, password) {
+		t.Errorf("password is not redacted: %#v", diags[0])
+	}
+	if diags[0].Summary != "Cannot connect with password "+redactedSecret {
+		t.Errorf("unexpected summary after redaction: %s", diags[0].Summary)
+	}
+
+	if redacted := redactSecrets("nothing to redact", ""); redacted != "nothing to redact" {
+		t.Errorf("an empty secret must not be redacted, got %s", redacted)
+	}
+}
+
+func TestIsBadRequestError(t *testing.T) {
+	if !isBadRequestError(runtime.NewAPIError("unknown error", nil, http.StatusBadRequest)) {
+		t.Errorf("expected a 400 response to be a bad request")
+	}
+
+	if isBadRequestError(runtime.NewAPIError("unknown error", nil, http.StatusInternalServerError)) {
+		t.Errorf("expected a 500 response not to be a bad request")
+	}
+
+	if isBadRequestError(errors.New("connection refused")) {
+		t.Errorf("expected an error without a response not to be a bad request")
+	}
+
+	if !isBadRequestError(cloud_account.NewCreateVSphereCloudAccountBadRequest()) {
+		t.Errorf("expected a declared 400 response to be a bad request")
+	}
+
+	if isBadRequestError(cloud_account.NewGetVSphereCloudAccountNotFound()) {
+		t.Errorf("expected a declared 404 response not to be a bad request")
+	}
+}
+
+func TestDiagFromCloudAccountValidationError(t *testing.T) {
+	var tests = []struct {
+		err       error
+		summary   string
+		attribute string
+	}{
+		{
+			&testAPIError{Payload: &testAPIErrorPayload{Message: "Cannot login: incorrect user name or password"}},
+			"cloud account validation failed, the credentials were rejected: Cannot login: incorrect user name or password",
+			"username",
+		},
+		{
+			&testAPIError{Payload: &testAPIErrorPayload{Message: "Unable to connect: PKIX path building failed, unable to find valid certification path"}},
+			"cloud account validation failed, the certificate of the endpoint is not trusted: Unable to connect: PKIX path building failed, unable to find valid certification path",
+			"accept_self_signed_cert",
+		},
+		{
+			&testAPIError{Payload: &testAPIErrorPayload{Message: "Cannot connect to hostName vcenter.corp"}},
+			"cloud account validation failed, the endpoint is unreachable: Cannot connect to hostName vcenter.corp",
+			"hostname",
+		},
+		{
+			errors.New("dial tcp 10.0.0.1:443: i/o timeout"),
+			"cloud account validation failed, the endpoint is unreachable: dial tcp 10.0.0.1:443: i/o timeout",
+			"hostname",
+		},
+		{
+			&testAPIError{Payload: &testAPIErrorPayload{Message: "Internal server error"}},
+			"Internal server error",
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		diags := diagFromCloudAccountValidationError(tt.err, cloudAccountVsphereAPIFields)
+		if len(diags) != 1 {
+			t.Fatalf("expected a single diagnostic for %q, got %#v", tt.err, diags)
+		}
+		if diags[0].Summary != tt.summary {
+			t.Errorf("expected summary %q, got %q", tt.summary, diags[0].Summary)
+		}
+		if tt.attribute == "" && diags[0].AttributePath != nil {
+			t.Errorf("expected %q not to be attributed, got %#v", tt.err, diags[0].AttributePath)
+		}
+		if tt.attribute != "" && !diags[0].AttributePath.Equals(cty.GetAttrPath(tt.attribute)) {
+			t.Errorf("expected %q to be attributed to %s, got %#v", tt.err, tt.attribute, diags[0].AttributePath)
+		}
+	}
+
+	// Field errors are kept as they are
+	diags := diagFromCloudAccountValidationError(&testAPIError{Payload: &testAPIErrorPayload{
+		Message:          "Invalid request",
+		ValidationErrors: map[string]string{"hostName": "must not be blank", "password": "must not be blank"},
+	}}, cloudAccountVsphereAPIFields)
+	if len(diags) != 2 || diags[0].Summary != "invalid value for hostname" {
+		t.Errorf("expected the field diagnostics to be kept, got %#v", diags)
+	}
+}
@@ -53,6 +53,22 @@ func flattenLinks(links map[string]models.Href) []map[string]interface{} {
 	return configLinks
 }
 
+// filterLinks returns only the links whose relation is in rels, or all links if rels is empty
+func filterLinks(links map[string]models.Href, rels []string) map[string]models.Href {
+	if len(rels) == 0 {
+		return links
+	}
+
+	filtered := make(map[string]models.Href, len(rels))
+	for _, rel := range rels {
+		if value, ok := links[rel]; ok {
+			filtered[rel] = value
+		}
+	}
+
+	return filtered
+}
+
 /*
 func getSelfLink(configLinks []interface{}) string {
 	for _, configLink := range configLinks {
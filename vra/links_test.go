@@ -0,0 +1,42 @@
+package vra
+
+import (
+	"testing"
+
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestFilterLinks(t *testing.T) {
+	links := map[string]models.Href{
+		"self":                      {Href: "/iaas/api/cloud-accounts/1"},
+		"regions":                   {Hrefs: []string{"/iaas/api/regions/2"}},
+		"associated-cloud-accounts": {Hrefs: []string{"/iaas/api/cloud-accounts/3"}},
+	}
+
+	if filtered := filterLinks(links, nil); len(filtered) != 3 {
+		t.Errorf("expected all links without a filter, got %#v", filtered)
+	}
+
+	filtered := filterLinks(links, []string{"self", "regions", "unknown"})
+	if len(filtered) != 2 {
+		t.Fatalf("expected only the whitelisted links, got %#v", filtered)
+	}
+
+	if _, ok := filtered["self"]; !ok {
+		t.Errorf("expected self link to be kept")
+	}
+
+	if _, ok := filtered["associated-cloud-accounts"]; ok {
+		t.Errorf("expected associated-cloud-accounts link to be filtered out")
+	}
+
+	flattened := flattenLinks(filtered)
+	if len(flattened) != 2 {
+		t.Errorf("expected 2 flattened links, got %#v", flattened)
+	}
+	for _, link := range flattened {
+		if rel := link["rel"]; rel != "self" && rel != "regions" {
+			t.Errorf("unexpected link %s after filtering", rel)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package vra
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testMockAPI is an in-memory stand-in for the subset of the vRA IaaS API used by the unit tests of the resources.
+// Requests without a registered handler fail with 404 Not Found.
+type testMockAPI struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []string
+	nextID   int
+
+	// cloudAccountsVsphere holds the vSphere cloud accounts by id, as returned by the API
+	cloudAccountsVsphere map[string]map[string]interface{}
+}
+
+// newTestMockAPI starts a mock API serving the vSphere cloud account endpoints, which is closed when the test ends
+func newTestMockAPI(t *testing.T) *testMockAPI {
+	api := &testMockAPI{
+		t:                    t,
+		handlers:             make(map[string]http.HandlerFunc),
+		cloudAccountsVsphere: make(map[string]map[string]interface{}),
+	}
+
+	api.handle(http.MethodPost, "/iaas/api/cloud-accounts-vsphere", api.createCloudAccountVsphere)
+	api.handle(http.MethodGet, "/iaas/api/cloud-accounts-vsphere", api.listCloudAccountsVsphere)
+	api.handle(http.MethodGet, "/iaas/api/cloud-accounts-vsphere/", api.getCloudAccountVsphere)
+	api.handle(http.MethodPatch, "/iaas/api/cloud-accounts-vsphere/", api.updateCloudAccountVsphere)
+	api.handle(http.MethodDelete, "/iaas/api/cloud-accounts-vsphere/", api.deleteCloudAccountVsphere)
+	api.handle(http.MethodGet, "/iaas/api/cloud-accounts/", api.getCloudAccount)
+
+	api.server = httptest.NewTLSServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(api.server.Close)
+
+	return api
+}
+
+// handle registers the handler of the requests with the method and path. A path ending with "/" matches every path
+// below it, the most specific path wins.
+func (api *testMockAPI) handle(method, path string, handler http.HandlerFunc) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.handlers[method+" "+path] = handler
+}
+
+// client returns a provider client sending its requests to the mock API
+func (api *testMockAPI) client() *Client {
+	apiClient, err := getAPIClient(api.server.URL, "token", true, "", defaultConnectionSettings)
+	if err != nil {
+		api.t.Fatalf("getAPIClient returned error %s", err)
+	}
+	return &Client{url: api.server.URL, apiClient: apiClient, apiTimeout: time.Minute}
+}
+
+// received returns the "METHOD path" of every request received so far
+func (api *testMockAPI) received() []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]string(nil), api.requests...)
+}
+
+func (api *testMockAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	api.requests = append(api.requests, r.Method+" "+r.URL.Path)
+	var handler http.HandlerFunc
+	matched := ""
+	for key, h := range api.handlers {
+		method, path := splitMockAPIKey(key)
+		if method != r.Method || len(path) <= len(matched) {
+			continue
+		}
+		if path == r.URL.Path || (strings.HasSuffix(path, "/") && strings.HasPrefix(r.URL.Path, path)) {
+			handler, matched = h, path
+		}
+	}
+	api.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if handler == nil {
+		api.writeError(w, http.StatusNotFound, fmt.Sprintf("no handler for %s %s", r.Method, r.URL.Path))
+		return
+	}
+	handler(w, r)
+}
+
+func splitMockAPIKey(key string) (string, string) {
+	parts := strings.SplitN(key, " ", 2)
+	return parts[0], parts[1]
+}
+
+// writeJSON writes the body encoded as JSON with the status code
+func (api *testMockAPI) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		api.t.Errorf("encoding the mock API response returned error %s", err)
+	}
+}
+
+// writeError writes an error in the format of the vRA API
+func (api *testMockAPI) writeError(w http.ResponseWriter, status int, message string) {
+	api.writeJSON(w, status, map[string]interface{}{"message": message, "statusCode": status})
+}
+
+func (api *testMockAPI) readJSON(r *http.Request) map[string]interface{} {
+	body := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		api.t.Errorf("decoding the mock API request of %s %s returned error %s", r.Method, r.URL.Path, err)
+	}
+	return body
+}
+
+// setCloudAccountVsphereRegions enables the regions on the cloud account. Like the real API, the enabled regions are
+// not returned in the requested order.
+func setCloudAccountVsphereRegions(account map[string]interface{}, regions []interface{}) {
+	enabledRegionIds := make([]interface{}, 0, len(regions))
+	hrefs := make([]interface{}, 0, len(regions))
+	for i := len(regions) - 1; i >= 0; i-- {
+		enabledRegionIds = append(enabledRegionIds, regions[i])
+		hrefs = append(hrefs, fmt.Sprintf("/iaas/api/regions/region-%s", strings.TrimPrefix(regions[i].(string), "Datacenter:")))
+	}
+	account["enabledRegionIds"] = enabledRegionIds
+	account["_links"].(map[string]interface{})["regions"] = map[string]interface{}{"hrefs": hrefs}
+}
+
+func (api *testMockAPI) createCloudAccountVsphere(w http.ResponseWriter, r *http.Request) {
+	spec := api.readJSON(r)
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	// Like the real API, a duplicate name is rejected without saying so
+	for _, account := range api.cloudAccountsVsphere {
+		if account["name"] == spec["name"] {
+			api.writeError(w, http.StatusBadRequest, "Invalid cloud account specification")
+			return
+		}
+	}
+
+	api.nextID++
+	id := fmt.Sprintf("cloud-account-%d", api.nextID)
+	account := map[string]interface{}{
+		"id":          id,
+		"name":        spec["name"],
+		"description": spec["description"],
+		"hostName":    spec["hostName"],
+		"username":    spec["username"],
+		"dcid":        spec["dcid"],
+		"tags":        spec["tags"],
+		"_links": map[string]interface{}{
+			"self": map[string]interface{}{"href": "/iaas/api/cloud-accounts-vsphere/" + id},
+		},
+	}
+	regions, _ := spec["regionIds"].([]interface{})
+	setCloudAccountVsphereRegions(account, regions)
+	api.cloudAccountsVsphere[id] = account
+
+	api.writeJSON(w, http.StatusCreated, account)
+}
+
+func (api *testMockAPI) listCloudAccountsVsphere(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	ids := make([]string, 0, len(api.cloudAccountsVsphere))
+	for id := range api.cloudAccountsVsphere {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	content := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		content = append(content, api.cloudAccountsVsphere[id])
+	}
+	api.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"content":       mockAPIPage(content, r),
+		"totalElements": len(content),
+	})
+}
+
+// mockAPIPage returns the page of the content requested with the $skip and $top query parameters
+func mockAPIPage(content []interface{}, r *http.Request) []interface{} {
+	skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+	top, err := strconv.Atoi(r.URL.Query().Get("$top"))
+	if err != nil {
+		top = len(content)
+	}
+	if skip > len(content) {
+		skip = len(content)
+	}
+	end := skip + top
+	if end > len(content) {
+		end = len(content)
+	}
+	return content[skip:end]
+}
+
+func (api *testMockAPI) getCloudAccountVsphere(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	account, ok := api.cloudAccountsVsphere[strings.TrimPrefix(r.URL.Path, "/iaas/api/cloud-accounts-vsphere/")]
+	if !ok {
+		api.writeError(w, http.StatusNotFound, "cloud account not found")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, account)
+}
+
+// getCloudAccount returns a cloud account of any type through the generic cloud account endpoint
+func (api *testMockAPI) getCloudAccount(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	account, ok := api.cloudAccountsVsphere[strings.TrimPrefix(r.URL.Path, "/iaas/api/cloud-accounts/")]
+	if !ok {
+		api.writeError(w, http.StatusNotFound, "cloud account not found")
+		return
+	}
+	api.writeJSON(w, http.StatusOK, account)
+}
+
+func (api *testMockAPI) updateCloudAccountVsphere(w http.ResponseWriter, r *http.Request) {
+	spec := api.readJSON(r)
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	account, ok := api.cloudAccountsVsphere[strings.TrimPrefix(r.URL.Path, "/iaas/api/cloud-accounts-vsphere/")]
+	if !ok {
+		api.writeError(w, http.StatusNotFound, "cloud account not found")
+		return
+	}
+	account["description"] = spec["description"]
+	account["tags"] = spec["tags"]
+	if username, ok := spec["username"]; ok {
+		account["username"] = username
+	}
+	if regions, ok := spec["regionIds"].([]interface{}); ok {
+		setCloudAccountVsphereRegions(account, regions)
+	}
+	if ids, ok := spec["associatedCloudAccountIds"].([]interface{}); ok {
+		hrefs := make([]interface{}, 0, len(ids))
+		for _, id := range ids {
+			hrefs = append(hrefs, "/iaas/api/cloud-accounts/"+id.(string))
+		}
+		account["_links"].(map[string]interface{})["associated-cloud-accounts"] = map[string]interface{}{"hrefs": hrefs}
+	}
+
+	api.writeJSON(w, http.StatusOK, account)
+}
+
+func (api *testMockAPI) deleteCloudAccountVsphere(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/iaas/api/cloud-accounts-vsphere/")
+	if _, ok := api.cloudAccountsVsphere[id]; !ok {
+		api.writeError(w, http.StatusNotFound, "cloud account not found")
+		return
+	}
+	delete(api.cloudAccountsVsphere, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,8 +2,11 @@ package vra
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider represents the VRA provider
@@ -42,6 +45,44 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "Specify timeout for how often to reauthorize the access token",
 			},
+			"api_timeout": {
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("VRA_API_TIMEOUT", nil),
+				Optional:    true,
+				Description: "Specify the timeout for API requests made by the provider, as a duration string such as \"90s\" or \"5m\". Defaults to 60s.",
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("VRA_USER_AGENT_SUFFIX", nil),
+				Optional:    true,
+				Description: "Specify a string to append to the User-Agent sent with every API request.",
+			},
+			"links_filter": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Restrict the links stored by cloud account resources to these relations, such as \"self\" or \"regions\". All links are stored when unset.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Default:      0,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of cloud account create, update and delete requests in flight at the same time. Unlimited when unset or 0.",
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Default:      100,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of idle connections to the API kept open for reuse. Defaults to 100.",
+			},
+			"idle_conn_timeout": {
+				Type:        schema.TypeString,
+				Default:     "90s",
+				Optional:    true,
+				Description: "Specify how long an idle connection to the API is kept open for reuse, as a duration string such as \"90s\" or \"5m\". Defaults to 90s.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -50,8 +91,10 @@ func Provider() *schema.Provider {
 			"vra_blueprint":                     dataSourceBlueprint(),
 			"vra_blueprint_version":             dataSourceBlueprintVersion(),
 			"vra_catalog_item":                  dataSourceCatalogItem(),
+			"vra_catalog_item_version":          dataSourceCatalogItemVersion(),
 			"vra_catalog_source_blueprint":      dataSourceCatalogSourceBlueprint(),
 			"vra_catalog_source_entitlement":    dataSourceCatalogSourceEntitlement(),
+			"vra_cloud_account":                 dataSourceCloudAccount(),
 			"vra_cloud_account_aws":             dataSourceCloudAccountAWS(),
 			"vra_cloud_account_azure":           dataSourceCloudAccountAzure(),
 			"vra_cloud_account_gcp":             dataSourceCloudAccountGCP(),
@@ -61,11 +104,13 @@ func Provider() *schema.Provider {
 			"vra_cloud_account_vsphere":         dataSourceCloudAccountVsphere(),
 			"vra_data_collector":                dataSourceDataCollector(),
 			"vra_deployment":                    dataSourceDeployment(),
+			"vra_external_network_ip_range":     dataSourceExternalNetworkIPRange(),
 			"vra_fabric_compute":                dataSourceFabricCompute(),
 			"vra_fabric_datastore_vsphere":      dataSourceFabricDatastoreVsphere(),
 			"vra_fabric_network":                dataSourceFabricNetwork(),
 			"vra_fabric_storage_account_azure":  dataSourceFabricStorageAccountAzure(),
 			"vra_fabric_storage_policy_vsphere": dataSourceFabricStoragePolicyVsphere(),
+			"vra_flavor":                        dataSourceFlavor(),
 			"vra_image":                         dataSourceImage(),
 			"vra_image_profile":                 dataSourceImageProfile(),
 			"vra_machine":                       dataSourceMachine(),
@@ -85,6 +130,7 @@ func Provider() *schema.Provider {
 			"vra_storage_profile_aws":           datasourceStorageProfileAws(),
 			"vra_storage_profile_azure":         datasourceStorageProfileAzure(),
 			"vra_storage_profile_vsphere":       dataSourceStorageProfileVsphere(),
+			"vra_tags":                          dataSourceTags(),
 			"vra_zone":                          dataSourceZone(),
 		},
 
@@ -104,16 +150,19 @@ func Provider() *schema.Provider {
 			"vra_cloud_account_vsphere":      resourceCloudAccountVsphere(),
 			"vra_content_source":             resourceContentSource(),
 			"vra_deployment":                 resourceDeployment(),
+			"vra_deployment_action":          resourceDeploymentAction(),
 			"vra_fabric_compute":             resourceFabricCompute(),
 			"vra_fabric_network_vsphere":     resourceFabricNetworkVsphere(),
 			"vra_flavor_profile":             resourceFlavorProfile(),
 			"vra_image_profile":              resourceImageProfile(),
 			"vra_load_balancer":              resourceLoadBalancer(),
 			"vra_machine":                    resourceMachine(),
+			"vra_machine_snapshot":           resourceMachineSnapshot(),
 			"vra_network":                    resourceNetwork(),
 			"vra_network_profile":            resourceNetworkProfile(),
 			"vra_network_ip_range":           resourceNetworkIPRange(),
 			"vra_project":                    resourceProject(),
+			"vra_security_group":             resourceSecurityGroup(),
 			"vra_storage_profile":            resourceStorageProfile(),
 			"vra_storage_profile_aws":        resourceStorageProfileAws(),
 			"vra_storage_profile_azure":      resourceStorageProfileAzure(),
@@ -140,18 +189,60 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	insecure := d.Get("insecure").(bool)
+	userAgentSuffix := d.Get("user_agent_suffix").(string)
 
 	if v, ok := d.GetOk("reauthorize_timeout"); ok {
 		reauth = v.(string)
 	}
 
+	apiTimeout := IncreasedTimeOut
+	if v, ok := d.GetOk("api_timeout"); ok {
+		t, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid api_timeout %q: %s", v.(string), err)
+		}
+		apiTimeout = t
+	}
+
+	conns, err := expandConnectionSettings(d)
+	if err != nil {
+		return nil, err
+	}
+
 	if accessToken == "" && refreshToken == "" {
 		return nil, errors.New("refresh_token or access_token required")
 	}
 
+	var c interface{}
 	if accessToken != "" {
-		return NewClientFromAccessToken(url, accessToken, insecure)
+		c, err = NewClientFromAccessToken(url, accessToken, insecure, userAgentSuffix, conns)
+	} else {
+		c, err = NewClientFromRefreshToken(url, refreshToken, insecure, reauth, userAgentSuffix, conns)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.(*Client).apiTimeout = apiTimeout
+	c.(*Client).linksFilter = expandStringList(d.Get("links_filter").(*schema.Set).List())
+	if v := d.Get("max_concurrent_requests").(int); v > 0 {
+		c.(*Client).requestSlots = make(chan struct{}, v)
+	}
+
+	return c, nil
+}
+
+// expandConnectionSettings returns the connection settings configured with max_idle_conns and idle_conn_timeout
+func expandConnectionSettings(d *schema.ResourceData) (connectionSettings, error) {
+	conns := defaultConnectionSettings
+	conns.maxIdleConns = d.Get("max_idle_conns").(int)
+
+	idleConnTimeout := d.Get("idle_conn_timeout").(string)
+	t, err := time.ParseDuration(idleConnTimeout)
+	if err != nil {
+		return conns, fmt.Errorf("invalid idle_conn_timeout %q: %s", idleConnTimeout, err)
 	}
+	conns.idleConnTimeout = t
 
-	return NewClientFromRefreshToken(url, refreshToken, insecure, reauth)
+	return conns, nil
 }
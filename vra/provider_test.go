@@ -1,9 +1,14 @@
 package vra
 
 import (
+	"errors"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,6 +22,62 @@ func init() {
 	}
 }
 
+// testSweepNamePrefixes are the name prefixes used by the acceptance tests for the resources they create.
+// Sweepers only delete resources whose names start with one of these prefixes.
+var testSweepNamePrefixes = []string{
+	"terraform-test-",
+	"my_vsphere_account_",
+	"my-nsxt-account-",
+	"my-nsxv-account-",
+}
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// sharedClientForSweepers returns a VRA client configured from the same environment variables as the provider.
+func sharedClientForSweepers() (*Client, error) {
+	url := os.Getenv("VRA_URL")
+	refreshToken := os.Getenv("VRA_REFRESH_TOKEN")
+	accessToken := os.Getenv("VRA_ACCESS_TOKEN")
+
+	if url == "" {
+		return nil, errors.New("VRA_URL must be set for sweepers")
+	}
+
+	if refreshToken == "" && accessToken == "" {
+		return nil, errors.New("VRA_REFRESH_TOKEN or VRA_ACCESS_TOKEN must be set for sweepers")
+	}
+
+	insecure, _ := strconv.ParseBool(os.Getenv("VRA7_INSECURE"))
+
+	var c interface{}
+	var err error
+	if accessToken != "" {
+		c, err = NewClientFromAccessToken(url, accessToken, insecure, os.Getenv("VRA_USER_AGENT_SUFFIX"), defaultConnectionSettings)
+	} else {
+		c, err = NewClientFromRefreshToken(url, refreshToken, insecure, "0", os.Getenv("VRA_USER_AGENT_SUFFIX"), defaultConnectionSettings)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.(*Client), nil
+}
+
+// sweeperPageSize is the number of resources the sweepers request per page
+const sweeperPageSize = 100
+
+// isSweepable determines whether a resource with the given name was created by the acceptance tests
+func isSweepable(name string) bool {
+	for _, prefix := range testSweepNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -27,6 +88,86 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProvider_apiTimeout(t *testing.T) {
+	var tests = []struct {
+		apiTimeout string
+		expected   time.Duration
+	}{
+		{"", IncreasedTimeOut},
+		{"90s", 90 * time.Second},
+		{"5m", 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		raw := map[string]interface{}{
+			"url":          "https://www.example.com",
+			"access_token": "token",
+		}
+		if tt.apiTimeout != "" {
+			raw["api_timeout"] = tt.apiTimeout
+		}
+
+		c, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, raw))
+		if err != nil {
+			t.Fatalf("configureProvider returned error %s", err)
+		}
+
+		if actual := c.(*Client).apiTimeout; actual != tt.expected {
+			t.Errorf("api_timeout %q expected %s, actual %s", tt.apiTimeout, tt.expected, actual)
+		}
+	}
+
+	raw := map[string]interface{}{
+		"url":          "https://www.example.com",
+		"access_token": "token",
+		"api_timeout":  "sixty",
+	}
+	if _, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, raw)); err == nil {
+		t.Errorf("configureProvider expected an error for an invalid api_timeout")
+	}
+}
+
+func TestProvider_connectionSettings(t *testing.T) {
+	var tests = []struct {
+		raw      map[string]interface{}
+		expected connectionSettings
+	}{
+		{map[string]interface{}{}, defaultConnectionSettings},
+		{map[string]interface{}{"max_idle_conns": 500, "idle_conn_timeout": "5m"}, connectionSettings{maxIdleConns: 500, idleConnTimeout: 5 * time.Minute}},
+	}
+
+	for _, tt := range tests {
+		tt.raw["url"] = "https://www.example.com"
+		tt.raw["access_token"] = "token"
+
+		conns, err := expandConnectionSettings(schema.TestResourceDataRaw(t, Provider().Schema, tt.raw))
+		if err != nil {
+			t.Fatalf("expandConnectionSettings returned error %s", err)
+		}
+		if conns != tt.expected {
+			t.Errorf("expected connection settings %+v, actual %+v", tt.expected, conns)
+		}
+
+		transport, err := createTransport(false, conns)
+		if err != nil {
+			t.Fatalf("createTransport returned error %s", err)
+		}
+		if transport.MaxIdleConns != conns.maxIdleConns || transport.MaxIdleConnsPerHost != conns.maxIdleConns || transport.IdleConnTimeout != conns.idleConnTimeout {
+			t.Errorf("expected the transport to use %+v, actual MaxIdleConns %d, MaxIdleConnsPerHost %d, IdleConnTimeout %s",
+				conns, transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+		}
+	}
+
+	raw := map[string]interface{}{
+		"url":               "https://www.example.com",
+		"access_token":      "token",
+		"idle_conn_timeout": "ninety",
+	}
+	if _, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, raw)); err == nil {
+		t.Errorf("configureProvider expected an error for an invalid idle_conn_timeout")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("VRA_URL"); v == "" {
 		t.Fatal("VRA_URL must be set for acceptance tests")
@@ -279,6 +420,15 @@ func testAccPreCheckVsphere(t *testing.T) {
 	}
 }
 
+// testAccPreCheckVsphereAlternateCredentials skips the test unless a second set of vCenter credentials is configured
+func testAccPreCheckVsphereAlternateCredentials(t *testing.T) {
+	testAccPreCheckVsphere(t)
+
+	if os.Getenv("VRA_VSPHERE_ALTERNATE_USERNAME") == "" || os.Getenv("VRA_VSPHERE_ALTERNATE_PASSWORD") == "" {
+		t.Skip("VRA_VSPHERE_ALTERNATE_USERNAME and VRA_VSPHERE_ALTERNATE_PASSWORD must be set to test out-of-band username changes")
+	}
+}
+
 func testAccPreCheckVsphereForDataStore(t *testing.T) {
 	testAccPreCheckVra(t)
 
@@ -2,6 +2,7 @@ package vra
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vra-sdk-go/pkg/models"
@@ -89,6 +90,71 @@ func flattenResources(resources []*models.DeploymentResource) []map[string]inter
 	return configResources
 }
 
+// resourceAddressesSchema returns the schema to use for the resource_addresses property
+func resourceAddressesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// flattenResourceAddresses returns the address of each resource by resource name. A resource without an address, such
+// as a network, maps to an empty string.
+func flattenResourceAddresses(resources []*models.DeploymentResource) map[string]interface{} {
+	resourceAddresses := make(map[string]interface{}, len(resources))
+
+	for _, value := range resources {
+		if value.Name == nil {
+			continue
+		}
+
+		address := ""
+		if properties, ok := value.Properties.(map[string]interface{}); ok {
+			if v, ok := properties["address"].(string); ok {
+				address = v
+			}
+		}
+		resourceAddresses[*value.Name] = address
+	}
+
+	return resourceAddresses
+}
+
+// resourcesByTypeSchema returns the schema to use for the resources_by_type property
+func resourcesByTypeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// flattenResourcesByType returns, for each resource type, the JSON encoded list of the sorted names of the resources
+// of this type. Terraform maps cannot hold lists, so the names are decoded with jsondecode in the configuration.
+func flattenResourcesByType(resources []*models.DeploymentResource) map[string]interface{} {
+	namesByType := make(map[string][]string)
+	for _, value := range resources {
+		if value.Type == nil || value.Name == nil {
+			continue
+		}
+		namesByType[*value.Type] = append(namesByType[*value.Type], *value.Name)
+	}
+
+	resourcesByType := make(map[string]interface{}, len(namesByType))
+	for resourceType, names := range namesByType {
+		sort.Strings(names)
+		namesJSON, _ := json.Marshal(names)
+		resourcesByType[resourceType] = string(namesJSON)
+	}
+
+	return resourcesByType
+}
+
 //func expandResources(configResources []interface{}) []*models.Resource {
 //	resources := make([]*models.Resource, 0, len(configResources))
 //
@@ -313,6 +313,10 @@ func resourceBlockDeviceUpdate(ctx context.Context, d *schema.ResourceData, m in
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
+	if d.HasChange("persistent") && !d.Get("persistent").(bool) {
+		return diag.Errorf("a persistent block device cannot be made non-persistent, block device %s", id)
+	}
+
 	if d.HasChange("capacity_in_gb") {
 		err := resizeDisk(ctx, d, apiClient, id)
 		if err != nil {
@@ -320,6 +324,13 @@ func resourceBlockDeviceUpdate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
+	if d.HasChange("persistent") {
+		err := promoteDisk(ctx, d, apiClient, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Printf("Finished updating vra_block_device resource with name %s", d.Get("name"))
 	return resourceBlockDeviceRead(ctx, d, m)
 }
@@ -342,7 +353,32 @@ func resizeDisk(ctx context.Context, d *schema.ResourceData, apiClient *client.M
 		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
 		Refresh:    blockDeviceStateRefreshFunc(*apiClient, *resizeBlockDeviceAccepted.Payload.ID),
 		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err = stateChangeFunc.WaitForStateContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// promoteDisk promotes the block device to a persistent block device, which survives the delete of its machine
+func promoteDisk(ctx context.Context, d *schema.ResourceData, apiClient *client.MulticloudIaaS, id string) error {
+	log.Printf("Starting promote of vra_block_device resource with name %s", d.Get("name"))
+
+	promoteDiskAccepted, err := apiClient.Disk.PromoteDisk(disk.NewPromoteDiskParams().WithID(id))
+	if err != nil {
+		return err
+	}
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:      5 * time.Second,
+		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
+		Refresh:    blockDeviceStateRefreshFunc(*apiClient, *promoteDiskAccepted.Payload.ID),
+		Target:     []string{models.RequestTrackerStatusFINISHED},
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
 		MinTimeout: 5 * time.Second,
 	}
 
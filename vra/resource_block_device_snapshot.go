@@ -35,6 +35,11 @@ func resourceBlockDeviceSnapshot() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"revert_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value which, when changed to a non-empty value, reverts the block device to this snapshot.",
+			},
 			"created_at": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -195,7 +200,35 @@ func resourceBlockDeviceSnapshotRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceBlockDeviceSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	return diag.Errorf("update vra_block_device_snapshot is not supported")
+	if d.HasChangeExcept("revert_trigger") {
+		return diag.Errorf("update vra_block_device_snapshot is not supported")
+	}
+
+	if d.Get("revert_trigger").(string) != "" {
+		blockDeviceID := d.Get("block_device_id").(string)
+		log.Printf("Reverting vra_block_device %s to vra_block_device_snapshot %s", blockDeviceID, d.Id())
+		apiClient := m.(*Client).apiClient
+
+		revertDiskSnapshotAccepted, err := apiClient.Disk.RevertDiskSnapshot(disk.NewRevertDiskSnapshotParams().WithPathID(blockDeviceID).WithQueryID(d.Id()))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		stateChangeFunc := resource.StateChangeConf{
+			Delay:      5 * time.Second,
+			Pending:    []string{models.RequestTrackerStatusINPROGRESS},
+			Refresh:    BlockDeviceSnapshotStateRefreshFunc(*apiClient, *revertDiskSnapshotAccepted.Payload.ID),
+			Target:     []string{models.RequestTrackerStatusFINISHED},
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			MinTimeout: 5 * time.Second,
+		}
+
+		if _, err = stateChangeFunc.WaitForStateContext(ctx); err != nil {
+			return diag.Errorf("error reverting block device %s to snapshot %s: %s", blockDeviceID, d.Id(), err)
+		}
+	}
+
+	return resourceBlockDeviceSnapshotRead(ctx, d, m)
 }
 
 func resourceBlockDeviceSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -1,7 +1,9 @@
 package vra
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"strconv"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -150,3 +153,60 @@ resource "vra_project" "this" {
 	  }
  }`, name, region, rInt, rInt)
 }
+
+func TestResourceBlockDeviceUpdate_persistent(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+
+	persistent := false
+	api.handle(http.MethodPost, "/iaas/api/block-devices/disk-1/operations/promote", func(w http.ResponseWriter, r *http.Request) {
+		persistent = true
+		api.writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "tracker", "status": "INPROGRESS", "progress": 0, "selfLink": "/iaas/api/request-tracker/tracker"})
+	})
+	api.handle(http.MethodGet, "/iaas/api/request-tracker/", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "tracker", "status": "FINISHED", "progress": 100,
+			"resources": []interface{}{"/iaas/api/block-devices/disk-1"}, "selfLink": r.URL.Path})
+	})
+	api.handle(http.MethodGet, "/iaas/api/block-devices/disk-1", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "disk-1", "name": "data", "capacityInGB": 10,
+			"persistent": persistent, "status": "AVAILABLE", "_links": map[string]interface{}{}})
+	})
+
+	r := resourceBlockDevice()
+	update := func(statePersistent, configPersistent bool) (*schema.ResourceData, error) {
+		state := &terraform.InstanceState{ID: "disk-1", Attributes: map[string]string{
+			"id": "disk-1", "capacity_in_gb": "10", "name": "data", "project_id": "project-1", "persistent": strconv.FormatBool(statePersistent),
+		}}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"capacity_in_gb": 10, "name": "data", "project_id": "project-1", "persistent": configPersistent,
+		})
+		diff, err := r.Diff(context.Background(), state, config, c)
+		if err != nil {
+			return nil, err
+		}
+		d, err := schema.InternalMap(r.Schema).Data(state, diff)
+		if err != nil {
+			return nil, err
+		}
+		if diags := r.UpdateContext(context.Background(), d, c); diags.HasError() {
+			return d, fmt.Errorf("%s", diags[0].Summary)
+		}
+		return d, nil
+	}
+
+	// A persistent block device cannot be made non-persistent
+	if _, err := update(true, false); err == nil {
+		t.Errorf("expected an error making a persistent block device non-persistent")
+	}
+	if persistent {
+		t.Fatalf("expected the block device not to be promoted")
+	}
+
+	d, err := update(false, true)
+	if err != nil {
+		t.Fatalf("update returned error %s", err)
+	}
+	if !persistent || !d.Get("persistent").(bool) {
+		t.Errorf("expected the block device to be promoted to persistent")
+	}
+}
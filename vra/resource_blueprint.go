@@ -2,8 +2,11 @@ package vra
 
 import (
 	"context"
+	"reflect"
+	"strings"
 
 	"github.com/vmware/vra-sdk-go/pkg/client/blueprint"
+	"gopkg.in/yaml.v2"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -25,8 +28,9 @@ func resourceBlueprint() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"content": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentBlueprintContent,
 			},
 			"content_source_id": {
 				Type:     schema.TypeString,
@@ -201,7 +205,7 @@ func resourceBlueprintRead(ctx context.Context, d *schema.ResourceData, m interf
 	blueprint := *resp.Payload
 	d.Set("content", blueprint.Content)
 	d.Set("content_source_id", blueprint.ContentSourceID)
-	d.Set("content_source_pat", blueprint.ContentSourcePath)
+	d.Set("content_source_path", blueprint.ContentSourcePath)
 	d.Set("content_source_sync_at", blueprint.ContentSourceSyncAt)
 	d.Set("content_source_sync_messages", blueprint.ContentSourceSyncMessages)
 	d.Set("content_source_sync_status", blueprint.ContentSourceSyncStatus)
@@ -292,3 +296,21 @@ func flattenValidationMessages(blueprintValidationMessages []*models.BlueprintVa
 
 	return validationMsgs
 }
+
+// suppressEquivalentBlueprintContent ignores differences of formatting, such as indentation, quoting or key order,
+// between two blueprint contents that parse to the same YAML document. Content that does not parse is compared as text,
+// ignoring leading and trailing whitespace.
+func suppressEquivalentBlueprintContent(k, old, new string, d *schema.ResourceData) bool {
+	if strings.TrimSpace(old) == strings.TrimSpace(new) {
+		return true
+	}
+
+	var oldContent, newContent interface{}
+	if err := yaml.Unmarshal([]byte(old), &oldContent); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(new), &newContent); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldContent, newContent)
+}
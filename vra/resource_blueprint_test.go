@@ -123,3 +123,24 @@ func testAccCheckVRABlueprintInvalidContentConfig(rInt int) string {
 				EOT
 	}`, rInt)
 }
+
+func TestSuppressEquivalentBlueprintContent(t *testing.T) {
+	content := "formatVersion: 1\ninputs: {}\nresources:\n  Cloud_Machine_1:\n    type: Cloud.Machine\n    properties:\n      image: ubuntu\n      flavor: small\n"
+
+	cases := []struct {
+		new      string
+		expected bool
+	}{
+		{content, true},
+		{"\n" + content + "\n\n", true},
+		{"formatVersion: 1\ninputs: {}\nresources:\n    Cloud_Machine_1:\n        properties: {flavor: 'small', image: \"ubuntu\"}\n        type: Cloud.Machine\n", true},
+		{"formatVersion: 1\ninputs: {}\nresources:\n  Cloud_Machine_1:\n    type: Cloud.Machine\n    properties:\n      image: ubuntu\n      flavor: medium\n", false},
+		{"resources:\n  - a\n b: [\n", false},
+	}
+
+	for _, c := range cases {
+		if actual := suppressEquivalentBlueprintContent("content", content, c.new, nil); actual != c.expected {
+			t.Errorf("content %q: expected suppressed diff %t, got %t", c.new, c.expected, actual)
+		}
+	}
+}
@@ -102,8 +102,13 @@ func resourceCatalogSourceEntitlementRead(ctx context.Context, d *schema.Resourc
 	log.Printf("Reading the vra_catalog_source_entitlement resource with name %s", d.Get("name"))
 	apiClient := m.(*Client).apiClient
 
-	resp, err := apiClient.CatalogEntitlements.GetEntitlementsUsingGET(
-		catalog_entitlements.NewGetEntitlementsUsingGETParams().WithProjectID(withString(d.Get("project_id").(string))))
+	// On import only the entitlement id is known, so all entitlements are listed when there is no project id
+	params := catalog_entitlements.NewGetEntitlementsUsingGETParams()
+	if v, ok := d.GetOk("project_id"); ok {
+		params = params.WithProjectID(withString(v.(string)))
+	}
+
+	resp, err := apiClient.CatalogEntitlements.GetEntitlementsUsingGET(params)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -111,13 +116,14 @@ func resourceCatalogSourceEntitlementRead(ctx context.Context, d *schema.Resourc
 
 	setFields := func(entitlement *models.Entitlement) {
 		d.SetId(entitlement.ID.String())
+		d.Set("catalog_source_id", entitlement.Definition.ID.String())
 		d.Set("project_id", entitlement.ProjectID)
 		d.Set("definition", flattenContentDefinition(entitlement.Definition))
 	}
 
 	if len(resp.Payload) > 0 {
 		for _, entitlement := range resp.Payload {
-			if entitlement.Definition.ID.String() == d.Get("catalog_source_id").(string) {
+			if entitlement.ID.String() == d.Id() || entitlement.Definition.ID.String() == d.Get("catalog_source_id").(string) {
 				setFields(entitlement)
 				log.Printf("Finished reading the vra_catalog_source_entitlement resource with name %s", d.Get("name"))
 				return nil
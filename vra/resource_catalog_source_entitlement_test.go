@@ -33,6 +33,11 @@ func TestAccVRACatalogSourceEntitlement_Valid(t *testing.T) {
 					resource.TestCheckResourceAttrPair(resource1, "project_id", project, "id"),
 				),
 			},
+			{
+				ResourceName:      resource1,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
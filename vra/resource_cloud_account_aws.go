@@ -37,6 +37,7 @@ func resourceCloudAccountAWS() *schema.Resource {
 				Sensitive: true,
 			},
 			// Optional arguments
+			"create_default_zones": createDefaultZonesSchema(),
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -48,7 +49,8 @@ func resourceCloudAccountAWS() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
-			"tags": tagsSchema(),
+			"tags":     cloudAccountTagsSchema(),
+			"tags_map": cloudAccountTagsMapSchema(),
 			// Computed attributes
 			"created_at": {
 				Type:     schema.TypeString,
@@ -74,6 +76,7 @@ func resourceCloudAccountAWS() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"zone_ids": cloudAccountZoneIdsSchema(),
 		},
 	}
 }
@@ -87,7 +90,7 @@ func resourceCloudAccountAWSCreate(ctx context.Context, d *schema.ResourceData,
 	description := d.Get("description").(string)
 	name := d.Get("name").(string)
 	secretAccessKey := d.Get("secret_key").(string)
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	tags := expandCloudAccountTags(d)
 
 	if v, ok := d.GetOk("regions"); ok {
 		if !compareUnique(v.(*schema.Set).List()) {
@@ -96,15 +99,20 @@ func resourceCloudAccountAWSCreate(ctx context.Context, d *schema.ResourceData,
 		regions = expandStringList(v.(*schema.Set).List())
 	}
 
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	createResp, err := apiClient.CloudAccount.CreateAwsCloudAccount(cloud_account.NewCreateAwsCloudAccountParams().WithBody(&models.CloudAccountAwsSpecification{
 		AccessKeyID:        &accessKey,
-		CreateDefaultZones: false,
+		CreateDefaultZones: d.Get("create_default_zones").(bool),
 		Description:        description,
 		Name:               &name,
 		SecretAccessKey:    &secretAccessKey,
 		RegionIds:          regions,
 		Tags:               tags,
 	}))
+	release()
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -118,7 +126,7 @@ func resourceCloudAccountAWSCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	d.Set("region_ids", regionsIds)
 
-	if err := d.Set("tags", flattenTags(tags)); err != nil {
+	if err := setCloudAccountTags(d, tags); err != nil {
 		return diag.Errorf("Error setting cloud account tags - error: %#v", err)
 	}
 	d.SetId(*createResp.Payload.ID)
@@ -151,7 +159,7 @@ func resourceCloudAccountAWSRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("regions", regions)
 	d.Set("updated_at", awsAccount.UpdatedAt)
 
-	if err := d.Set("links", flattenLinks(awsAccount.Links)); err != nil {
+	if err := d.Set("links", flattenLinks(filterLinks(awsAccount.Links, m.(*Client).linksFilter))); err != nil {
 		return diag.Errorf("error setting cloud_account_aws links - error: %#v", err)
 	}
 
@@ -163,10 +171,14 @@ func resourceCloudAccountAWSRead(ctx context.Context, d *schema.ResourceData, m
 	}
 	d.Set("region_ids", regionsIds)
 
-	if err := d.Set("tags", flattenTags(awsAccount.Tags)); err != nil {
+	if err := setCloudAccountTags(d, awsAccount.Tags); err != nil {
 		return diag.Errorf("Error setting cloud account tags - error: %#v", err)
 	}
 
+	if err := setCloudAccountZoneIds(d, apiClient, m.(*Client).apiTimeout); err != nil {
+		return diag.Errorf("Error setting cloud account zone_ids - error: %#v", err)
+	}
+
 	return nil
 }
 
@@ -177,7 +189,7 @@ func resourceCloudAccountAWSUpdate(ctx context.Context, d *schema.ResourceData,
 
 	id := d.Id()
 	description := d.Get("description").(string)
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	tags := expandCloudAccountTags(d)
 
 	if v, ok := d.GetOk("regions"); ok {
 		if !compareUnique(v.(*schema.Set).List()) {
@@ -185,12 +197,17 @@ func resourceCloudAccountAWSUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 		regions = expandStringList(v.(*schema.Set).List())
 	}
-	_, err := apiClient.CloudAccount.UpdateAwsCloudAccount(cloud_account.NewUpdateAwsCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountAwsSpecification{
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.UpdateAwsCloudAccount(cloud_account.NewUpdateAwsCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountAwsSpecification{
 		CreateDefaultZones: false,
 		Description:        description,
 		RegionIds:          regions,
 		Tags:               tags,
 	}))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -202,7 +219,12 @@ func resourceCloudAccountAWSDelete(ctx context.Context, d *schema.ResourceData,
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
-	_, err := apiClient.CloudAccount.DeleteAwsCloudAccount(cloud_account.NewDeleteAwsCloudAccountParams().WithID(id))
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.DeleteAwsCloudAccount(cloud_account.NewDeleteAwsCloudAccountParams().WithID(id))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -44,6 +44,7 @@ func resourceCloudAccountAzure() *schema.Resource {
 				Required: true,
 			},
 			// Optional arguments
+			"create_default_zones": createDefaultZonesSchema(),
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -55,7 +56,8 @@ func resourceCloudAccountAzure() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
-			"tags": tagsSchema(),
+			"tags":     cloudAccountTagsSchema(),
+			"tags_map": cloudAccountTagsMapSchema(),
 			//Computed attributes
 			"created_at": {
 				Type:     schema.TypeString,
@@ -81,6 +83,7 @@ func resourceCloudAccountAzure() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"zone_ids": cloudAccountZoneIdsSchema(),
 		},
 	}
 }
@@ -99,6 +102,10 @@ func resourceCloudAccountAzureCreate(ctx context.Context, d *schema.ResourceData
 
 	applicationKey := d.Get("application_key").(string)
 
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	createResp, err := apiClient.CloudAccount.CreateAzureCloudAccount(cloud_account.NewCreateAzureCloudAccountParams().WithBody(&models.CloudAccountAzureSpecification{
 		Description:                d.Get("description").(string),
 		Name:                       withString(d.Get("name").(string)),
@@ -106,10 +113,11 @@ func resourceCloudAccountAzureCreate(ctx context.Context, d *schema.ResourceData
 		ClientApplicationSecretKey: &applicationKey,
 		SubscriptionID:             withString(d.Get("subscription_id").(string)),
 		TenantID:                   withString(d.Get("tenant_id").(string)),
-		CreateDefaultZones:         false,
+		CreateDefaultZones:         d.Get("create_default_zones").(bool),
 		RegionIds:                  regions,
-		Tags:                       expandTags(d.Get("tags").(*schema.Set).List()),
+		Tags:                       expandCloudAccountTags(d),
 	}))
+	release()
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -148,7 +156,7 @@ func resourceCloudAccountAzureRead(ctx context.Context, d *schema.ResourceData,
 	d.Set("tenant_id", azureAccount.TenantID)
 	d.Set("updated_at", azureAccount.UpdatedAt)
 
-	if err := d.Set("links", flattenLinks(azureAccount.Links)); err != nil {
+	if err := d.Set("links", flattenLinks(filterLinks(azureAccount.Links, m.(*Client).linksFilter))); err != nil {
 		return diag.Errorf("error setting cloud_account_azure links - error: %#v", err)
 	}
 
@@ -160,10 +168,14 @@ func resourceCloudAccountAzureRead(ctx context.Context, d *schema.ResourceData,
 	}
 	d.Set("region_ids", regionsIds)
 
-	if err := d.Set("tags", flattenTags(azureAccount.Tags)); err != nil {
+	if err := setCloudAccountTags(d, azureAccount.Tags); err != nil {
 		return diag.Errorf("Error setting cloud account tags - error: %#v", err)
 	}
 
+	if err := setCloudAccountZoneIds(d, apiClient, m.(*Client).apiTimeout); err != nil {
+		return diag.Errorf("Error setting cloud account zone_ids - error: %#v", err)
+	}
+
 	return nil
 }
 
@@ -180,14 +192,19 @@ func resourceCloudAccountAzureUpdate(ctx context.Context, d *schema.ResourceData
 		}
 		regions = expandStringList(v.(*schema.Set).List())
 	}
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	tags := expandCloudAccountTags(d)
 
-	_, err := apiClient.CloudAccount.UpdateAzureCloudAccount(cloud_account.NewUpdateAzureCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountAzureSpecification{
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.UpdateAzureCloudAccount(cloud_account.NewUpdateAzureCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountAzureSpecification{
 		Description:        d.Get("description").(string),
 		CreateDefaultZones: false,
 		RegionIds:          regions,
 		Tags:               tags,
 	}))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -199,7 +216,12 @@ func resourceCloudAccountAzureDelete(ctx context.Context, d *schema.ResourceData
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
-	_, err := apiClient.CloudAccount.DeleteAzureCloudAccount(cloud_account.NewDeleteAzureCloudAccountParams().WithID(id))
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.DeleteAzureCloudAccount(cloud_account.NewDeleteAzureCloudAccountParams().WithID(id))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -45,6 +45,7 @@ func resourceCloudAccountGCP() *schema.Resource {
 				Required: true,
 			},
 			// Optional arguments
+			"create_default_zones": createDefaultZonesSchema(),
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -56,7 +57,8 @@ func resourceCloudAccountGCP() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
-			"tags": tagsSchema(),
+			"tags":     cloudAccountTagsSchema(),
+			"tags_map": cloudAccountTagsMapSchema(),
 			// Computed attributes
 			"created_at": {
 				Type:     schema.TypeString,
@@ -82,6 +84,7 @@ func resourceCloudAccountGCP() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"zone_ids": cloudAccountZoneIdsSchema(),
 		},
 	}
 }
@@ -98,6 +101,10 @@ func resourceCloudAccountGCPCreate(ctx context.Context, d *schema.ResourceData,
 		regions = expandStringList(v.(*schema.Set).List())
 	}
 
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	createResp, err := apiClient.CloudAccount.CreateGcpCloudAccount(cloud_account.NewCreateGcpCloudAccountParams().WithBody(&models.CloudAccountGcpSpecification{
 		Description:        d.Get("description").(string),
 		Name:               withString(d.Get("name").(string)),
@@ -105,10 +112,11 @@ func resourceCloudAccountGCPCreate(ctx context.Context, d *schema.ResourceData,
 		PrivateKey:         withString(d.Get("private_key").(string)),
 		PrivateKeyID:       withString(d.Get("private_key_id").(string)),
 		ProjectID:          withString(d.Get("project_id").(string)),
-		CreateDefaultZones: false,
+		CreateDefaultZones: d.Get("create_default_zones").(bool),
 		RegionIds:          regions,
-		Tags:               expandTags(d.Get("tags").(*schema.Set).List()),
+		Tags:               expandCloudAccountTags(d),
 	}))
+	release()
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -146,7 +154,7 @@ func resourceCloudAccountGCPRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("regions", regions)
 	d.Set("updated_at", gcpAccount.UpdatedAt)
 
-	if err := d.Set("links", flattenLinks(gcpAccount.Links)); err != nil {
+	if err := d.Set("links", flattenLinks(filterLinks(gcpAccount.Links, m.(*Client).linksFilter))); err != nil {
 		return diag.Errorf("error setting cloud_account_gcp links - error: %#v", err)
 	}
 
@@ -158,10 +166,14 @@ func resourceCloudAccountGCPRead(ctx context.Context, d *schema.ResourceData, m
 	}
 	d.Set("region_ids", regionsIds)
 
-	if err := d.Set("tags", flattenTags(gcpAccount.Tags)); err != nil {
+	if err := setCloudAccountTags(d, gcpAccount.Tags); err != nil {
 		return diag.Errorf("error setting cloud account tags - error: %#v", err)
 	}
 
+	if err := setCloudAccountZoneIds(d, apiClient, m.(*Client).apiTimeout); err != nil {
+		return diag.Errorf("error setting cloud account zone_ids - error: %#v", err)
+	}
+
 	return nil
 }
 
@@ -178,14 +190,19 @@ func resourceCloudAccountGCPUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 		regions = expandStringList(v.(*schema.Set).List())
 	}
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	tags := expandCloudAccountTags(d)
 
-	_, err := apiClient.CloudAccount.UpdateGcpCloudAccount(cloud_account.NewUpdateGcpCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountGcpSpecification{
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.UpdateGcpCloudAccount(cloud_account.NewUpdateGcpCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountGcpSpecification{
 		Description:        d.Get("description").(string),
 		CreateDefaultZones: false,
 		RegionIds:          regions,
 		Tags:               tags,
 	}))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -197,7 +214,12 @@ func resourceCloudAccountGCPDelete(ctx context.Context, d *schema.ResourceData,
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
-	_, err := apiClient.CloudAccount.DeleteGcpCloudAccount(cloud_account.NewDeleteGcpCloudAccountParams().WithID(id))
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.DeleteGcpCloudAccount(cloud_account.NewDeleteGcpCloudAccountParams().WithID(id))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -65,7 +65,8 @@ func resourceCloudAccountNSXT() *schema.Resource {
 				Optional:    true,
 				Description: "Create NSX-T cloud account in Manager (legacy) mode. When set to true, NSX-T cloud account is created in Manager mode. Mode cannot be changed after cloud account is created. Default value is false.",
 			},
-			"tags": tagsSchema(),
+			"tags":     cloudAccountTagsSchema(),
+			"tags_map": cloudAccountTagsMapSchema(),
 			// Computed attributes
 			"associated_cloud_account_ids": {
 				Type:     schema.TypeSet,
@@ -102,11 +103,15 @@ func resourceCloudAccountNSXT() *schema.Resource {
 func resourceCloudAccountNSXTCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*Client).apiClient
 
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	tags := expandCloudAccountTags(d)
 
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	createResp, err := apiClient.CloudAccount.CreateNsxTCloudAccount(
 		cloud_account.NewCreateNsxTCloudAccountParams().
-			WithTimeout(IncreasedTimeOut).
+			WithTimeout(m.(*Client).apiTimeout).
 			WithBody(&models.CloudAccountNsxTSpecification{
 				AcceptSelfSignedCertificate: d.Get("accept_self_signed_cert").(bool),
 				Dcid:                        withString(d.Get("dc_id").(string)),
@@ -118,12 +123,13 @@ func resourceCloudAccountNSXTCreate(ctx context.Context, d *schema.ResourceData,
 				Tags:                        tags,
 				Username:                    withString(d.Get("username").(string)),
 			}))
+	release()
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("tags", flattenTags(tags)); err != nil {
+	if err := setCloudAccountTags(d, tags); err != nil {
 		return diag.Errorf("error setting cloud account tags - error: %#v", err)
 	}
 	d.SetId(*createResp.Payload.ID)
@@ -156,11 +162,11 @@ func resourceCloudAccountNSXTRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("updated_at", nsxtAccount.UpdatedAt)
 	d.Set("username", nsxtAccount.Username)
 
-	if err := d.Set("links", flattenLinks(nsxtAccount.Links)); err != nil {
+	if err := d.Set("links", flattenLinks(filterLinks(nsxtAccount.Links, m.(*Client).linksFilter))); err != nil {
 		return diag.Errorf("error setting cloud_account_nsxt links - error: %#v", err)
 	}
 
-	if err := d.Set("tags", flattenTags(nsxtAccount.Tags)); err != nil {
+	if err := setCloudAccountTags(d, nsxtAccount.Tags); err != nil {
 		return diag.Errorf("error setting cloud account tags - error: %#v", err)
 	}
 
@@ -172,10 +178,15 @@ func resourceCloudAccountNSXTUpdate(ctx context.Context, d *schema.ResourceData,
 
 	id := d.Id()
 
-	_, err := apiClient.CloudAccount.UpdateNsxTCloudAccount(cloud_account.NewUpdateNsxTCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountNsxTSpecification{
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.UpdateNsxTCloudAccount(cloud_account.NewUpdateNsxTCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountNsxTSpecification{
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d.Get("tags").(*schema.Set).List()),
+		Tags:        expandCloudAccountTags(d),
 	}))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -187,7 +198,12 @@ func resourceCloudAccountNSXTDelete(ctx context.Context, d *schema.ResourceData,
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
-	_, err := apiClient.CloudAccount.DeleteCloudAccountNsxT(cloud_account.NewDeleteCloudAccountNsxTParams().WithID(id))
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.DeleteCloudAccountNsxT(cloud_account.NewDeleteCloudAccountNsxTParams().WithID(id))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -2,6 +2,7 @@ package vra
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"regexp"
 	"strconv"
@@ -13,6 +14,52 @@ import (
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 )
 
+func init() {
+	resource.AddTestSweepers("vra_cloud_account_nsxt", &resource.Sweeper{
+		Name:         "vra_cloud_account_nsxt",
+		Dependencies: []string{"vra_cloud_account_vsphere"},
+		F:            testSweepCloudAccountNSXT,
+	})
+}
+
+func testSweepCloudAccountNSXT(region string) error {
+	c, err := sharedClientForSweepers()
+	if err != nil {
+		return err
+	}
+	apiClient := c.apiClient
+
+	// Collect the accounts from every page first, deleting while paging would shift the offsets
+	accounts := make(map[string]string)
+	for skip := 0; ; skip += sweeperPageSize {
+		getResp, err := apiClient.CloudAccount.GetNsxTCloudAccounts(cloud_account.NewGetNsxTCloudAccountsParams(), withPage(skip, sweeperPageSize))
+		if err != nil {
+			return fmt.Errorf("error listing NSX-T cloud accounts: %s", err)
+		}
+
+		page := getResp.Payload.Content
+		for _, account := range page {
+			if account.ID != nil && isSweepable(account.Name) {
+				accounts[*account.ID] = account.Name
+			}
+		}
+
+		if len(page) < sweeperPageSize {
+			break
+		}
+	}
+
+	for id, name := range accounts {
+		log.Printf("[INFO] Deleting NSX-T cloud account %s (%s)", name, id)
+		_, err := apiClient.CloudAccount.DeleteCloudAccountNsxT(cloud_account.NewDeleteCloudAccountNsxTParams().WithID(id))
+		if err != nil {
+			log.Printf("[ERROR] Failed to delete NSX-T cloud account %s: %s", id, err)
+		}
+	}
+
+	return nil
+}
+
 func TestAccVRACloudAccountNSXT_Basic(t *testing.T) {
 	rInt := acctest.RandInt()
 	const nsxtAccount = "vra_cloud_account_nsxt.this"
@@ -53,7 +53,8 @@ func resourceCloudAccountNSXV() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			"tags": tagsSchema(),
+			"tags":     cloudAccountTagsSchema(),
+			"tags_map": cloudAccountTagsMapSchema(),
 			// Computed attributes
 			"associated_cloud_account_ids": {
 				Type:     schema.TypeSet,
@@ -86,11 +87,15 @@ func resourceCloudAccountNSXV() *schema.Resource {
 func resourceCloudAccountNSXVCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*Client).apiClient
 
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	tags := expandCloudAccountTags(d)
 
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	createResp, err := apiClient.CloudAccount.CreateNsxVCloudAccount(
 		cloud_account.NewCreateNsxVCloudAccountParams().
-			WithTimeout(IncreasedTimeOut).
+			WithTimeout(m.(*Client).apiTimeout).
 			WithBody(&models.CloudAccountNsxVSpecification{
 				AcceptSelfSignedCertificate: d.Get("accept_self_signed_cert").(bool),
 				Dcid:                        withString(d.Get("dc_id").(string)),
@@ -101,12 +106,13 @@ func resourceCloudAccountNSXVCreate(ctx context.Context, d *schema.ResourceData,
 				Tags:                        tags,
 				Username:                    withString(d.Get("username").(string)),
 			}))
+	release()
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("tags", flattenTags(tags)); err != nil {
+	if err := setCloudAccountTags(d, tags); err != nil {
 		return diag.Errorf("error setting cloud account tags - error: %#v", err)
 	}
 	d.SetId(*createResp.Payload.ID)
@@ -132,17 +138,18 @@ func resourceCloudAccountNSXVRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("created_at", nsxvAccount.CreatedAt)
 	d.Set("dc_id", nsxvAccount.Dcid)
 	d.Set("description", nsxvAccount.Description)
+	d.Set("hostname", nsxvAccount.HostName)
 	d.Set("name", nsxvAccount.Name)
 	d.Set("org_id", nsxvAccount.OrgID)
 	d.Set("owner", nsxvAccount.Owner)
 	d.Set("updated_at", nsxvAccount.UpdatedAt)
 	d.Set("username", nsxvAccount.Username)
 
-	if err := d.Set("links", flattenLinks(nsxvAccount.Links)); err != nil {
+	if err := d.Set("links", flattenLinks(filterLinks(nsxvAccount.Links, m.(*Client).linksFilter))); err != nil {
 		return diag.Errorf("error setting cloud_account_nsxv links - error: %#v", err)
 	}
 
-	if err := d.Set("tags", flattenTags(nsxvAccount.Tags)); err != nil {
+	if err := setCloudAccountTags(d, nsxvAccount.Tags); err != nil {
 		return diag.Errorf("error setting cloud account tags - error: %#v", err)
 	}
 
@@ -154,10 +161,15 @@ func resourceCloudAccountNSXVUpdate(ctx context.Context, d *schema.ResourceData,
 
 	id := d.Id()
 
-	_, err := apiClient.CloudAccount.UpdateNsxVCloudAccount(cloud_account.NewUpdateNsxVCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountNsxVSpecification{
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.UpdateNsxVCloudAccount(cloud_account.NewUpdateNsxVCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountNsxVSpecification{
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d.Get("tags").(*schema.Set).List()),
+		Tags:        expandCloudAccountTags(d),
 	}))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -169,7 +181,12 @@ func resourceCloudAccountNSXVDelete(ctx context.Context, d *schema.ResourceData,
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
-	_, err := apiClient.CloudAccount.DeleteCloudAccountNsxV(cloud_account.NewDeleteCloudAccountNsxVParams().WithID(id))
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.DeleteCloudAccountNsxV(cloud_account.NewDeleteCloudAccountNsxVParams().WithID(id))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
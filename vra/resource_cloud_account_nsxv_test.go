@@ -2,6 +2,7 @@ package vra
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"regexp"
 	"strconv"
@@ -13,6 +14,52 @@ import (
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 )
 
+func init() {
+	resource.AddTestSweepers("vra_cloud_account_nsxv", &resource.Sweeper{
+		Name:         "vra_cloud_account_nsxv",
+		Dependencies: []string{"vra_cloud_account_vsphere"},
+		F:            testSweepCloudAccountNSXV,
+	})
+}
+
+func testSweepCloudAccountNSXV(region string) error {
+	c, err := sharedClientForSweepers()
+	if err != nil {
+		return err
+	}
+	apiClient := c.apiClient
+
+	// Collect the accounts from every page first, deleting while paging would shift the offsets
+	accounts := make(map[string]string)
+	for skip := 0; ; skip += sweeperPageSize {
+		getResp, err := apiClient.CloudAccount.GetNsxVCloudAccounts(cloud_account.NewGetNsxVCloudAccountsParams(), withPage(skip, sweeperPageSize))
+		if err != nil {
+			return fmt.Errorf("error listing NSX-V cloud accounts: %s", err)
+		}
+
+		page := getResp.Payload.Content
+		for _, account := range page {
+			if account.ID != nil && isSweepable(account.Name) {
+				accounts[*account.ID] = account.Name
+			}
+		}
+
+		if len(page) < sweeperPageSize {
+			break
+		}
+	}
+
+	for id, name := range accounts {
+		log.Printf("[INFO] Deleting NSX-V cloud account %s (%s)", name, id)
+		_, err := apiClient.CloudAccount.DeleteCloudAccountNsxV(cloud_account.NewDeleteCloudAccountNsxVParams().WithID(id))
+		if err != nil {
+			log.Printf("[ERROR] Failed to delete NSX-V cloud account %s: %s", id, err)
+		}
+	}
+
+	return nil
+}
+
 func TestAccVRACloudAccountNSXV_Basic(t *testing.T) {
 	rInt := acctest.RandInt()
 	const nsxvAccount = "vra_cloud_account_nsxv.this"
@@ -58,6 +105,12 @@ func TestAccVRACloudAccountNSXV_Basic(t *testing.T) {
 						nsxvAccount, "tags.#", "2"),
 				),
 			},
+			{
+				ResourceName:            nsxvAccount,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"accept_self_signed_cert", "password"},
+			},
 		},
 	})
 }
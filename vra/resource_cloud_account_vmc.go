@@ -66,6 +66,7 @@ func resourceCloudAccountVMC() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"create_default_zones": createDefaultZonesSchema(),
 			"dc_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -74,7 +75,8 @@ func resourceCloudAccountVMC() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			"tags": tagsSchema(),
+			"tags":     cloudAccountTagsSchema(),
+			"tags_map": cloudAccountTagsMapSchema(),
 			// Computed attributes
 			"created_at": {
 				Type:     schema.TypeString,
@@ -100,6 +102,7 @@ func resourceCloudAccountVMC() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"zone_ids": cloudAccountZoneIdsSchema(),
 		},
 	}
 }
@@ -109,7 +112,7 @@ func resourceCloudAccountVMCCreate(ctx context.Context, d *schema.ResourceData,
 
 	apiClient := m.(*Client).apiClient
 
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	tags := expandCloudAccountTags(d)
 	if v, ok := d.GetOk("regions"); ok {
 		if !compareUnique(v.(*schema.Set).List()) {
 			return diag.FromErr(errors.New("specified regions are not unique"))
@@ -125,14 +128,18 @@ func resourceCloudAccountVMCCreate(ctx context.Context, d *schema.ResourceData,
 	cloudAccountProperties["nsxHostName"] = d.Get("nsx_hostname").(string)
 	cloudAccountProperties["sddcId"] = d.Get("sddc_name").(string)
 
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	createResp, err := apiClient.CloudAccount.CreateCloudAccount(
 		cloud_account.NewCreateCloudAccountParams().
-			WithTimeout(IncreasedTimeOut).
+			WithTimeout(m.(*Client).apiTimeout).
 			WithBody(&models.CloudAccountSpecification{
 				AssociatedCloudAccountIds: []string{},
 				CloudAccountProperties:    cloudAccountProperties,
 				CloudAccountType:          withString("vmc"),
-				CreateDefaultZones:        false,
+				CreateDefaultZones:        d.Get("create_default_zones").(bool),
 				Description:               d.Get("description").(string),
 				Name:                      withString(d.Get("name").(string)),
 				PrivateKey:                withString(d.Get("vcenter_password").(string)),
@@ -140,6 +147,7 @@ func resourceCloudAccountVMCCreate(ctx context.Context, d *schema.ResourceData,
 				RegionIds:                 regions,
 				Tags:                      tags,
 			}))
+	release()
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -153,7 +161,7 @@ func resourceCloudAccountVMCCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	d.Set("region_ids", regionsIds)
 
-	if err := d.Set("tags", flattenTags(tags)); err != nil {
+	if err := setCloudAccountTags(d, tags); err != nil {
 		return diag.Errorf("error setting cloud account tags - error: %#v", err)
 	}
 	d.SetId(*createResp.Payload.ID)
@@ -190,7 +198,7 @@ func resourceCloudAccountVMCRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("vcenter_hostname", vmcAccount.CloudAccountProperties["hostName"])
 	d.Set("vcenter_username", vmcAccount.CloudAccountProperties["privateKeyId"])
 
-	if err := d.Set("links", flattenLinks(vmcAccount.Links)); err != nil {
+	if err := d.Set("links", flattenLinks(filterLinks(vmcAccount.Links, m.(*Client).linksFilter))); err != nil {
 		return diag.Errorf("error setting cloud_account_vmc links - error: %#v", err)
 	}
 
@@ -202,10 +210,14 @@ func resourceCloudAccountVMCRead(ctx context.Context, d *schema.ResourceData, m
 	}
 	d.Set("region_ids", regionsIds)
 
-	if err := d.Set("tags", flattenTags(vmcAccount.Tags)); err != nil {
+	if err := setCloudAccountTags(d, vmcAccount.Tags); err != nil {
 		return diag.Errorf("error setting cloud account tags - error: %#v", err)
 	}
 
+	if err := setCloudAccountZoneIds(d, apiClient, m.(*Client).apiTimeout); err != nil {
+		return diag.Errorf("error setting cloud account zone_ids - error: %#v", err)
+	}
+
 	return nil
 }
 
@@ -223,12 +235,17 @@ func resourceCloudAccountVMCUpdate(ctx context.Context, d *schema.ResourceData,
 		regions = expandStringList(v.(*schema.Set).List())
 	}
 
-	_, err := apiClient.CloudAccount.UpdateCloudAccount(cloud_account.NewUpdateCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountSpecification{
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.UpdateCloudAccount(cloud_account.NewUpdateCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountSpecification{
 		CreateDefaultZones: false,
 		Description:        d.Get("description").(string),
 		RegionIds:          regions,
-		Tags:               expandTags(d.Get("tags").(*schema.Set).List()),
+		Tags:               expandCloudAccountTags(d),
 	}))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -240,7 +257,12 @@ func resourceCloudAccountVMCDelete(ctx context.Context, d *schema.ResourceData,
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
-	_, err := apiClient.CloudAccount.DeleteCloudAccount(cloud_account.NewDeleteCloudAccountParams().WithID(id))
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.DeleteCloudAccount(cloud_account.NewDeleteCloudAccountParams().WithID(id))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -3,111 +3,268 @@ package vra
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
+	"github.com/vmware/vra-sdk-go/pkg/client/fabric_vsphere_datastore"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// cloudAccountTypeVsphere is the cloudAccountType of vSphere cloud accounts in the generic cloud account API
+const cloudAccountTypeVsphere = "vsphere"
+
+// cloudAccountsPageSize is the number of cloud accounts requested per page
+const cloudAccountsPageSize = 100
+
+// cloudAccountVsphereRetryTimeout bounds the retries of the association and of the wait for the enumeration when no
+// timeout is configured for the operation
+const cloudAccountVsphereRetryTimeout = 5 * time.Minute
+
 func resourceCloudAccountVsphere() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceCloudAccountVsphereCreate,
-		ReadContext:   resourceCloudAccountVsphereRead,
-		UpdateContext: resourceCloudAccountVsphereUpdate,
-		DeleteContext: resourceCloudAccountVsphereDelete,
+		CreateContext: withSecretsRedacted(resourceCloudAccountVsphereCreate, "password"),
+		ReadContext:   withSecretsRedacted(resourceCloudAccountVsphereRead, "password"),
+		UpdateContext: withSecretsRedacted(resourceCloudAccountVsphereUpdate, "password"),
+		DeleteContext: withSecretsRedacted(resourceCloudAccountVsphereDelete, "password"),
+		CustomizeDiff: resourceCloudAccountVsphereCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceCloudAccountVsphereImport,
 		},
 
-		Schema: map[string]*schema.Schema{
-			// Required arguments
-			"hostname": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceCloudAccountVsphereV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceCloudAccountVsphereStateUpgradeV0,
 			},
-			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
-			},
-			"regions": {
-				Type:     schema.TypeSet,
-				Required: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+		},
+
+		Schema: resourceCloudAccountVsphereSchema(),
+
+		// The timeouts default to zero, so that a timeout configured for an operation can be told apart from an unset
+		// one, see requestTimeout
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(0)),
+			Update: schema.DefaultTimeout(time.Duration(0)),
+			Delete: schema.DefaultTimeout(time.Duration(0)),
+		},
+	}
+}
+
+func resourceCloudAccountVsphereSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Required arguments
+		"hostname": {
+			Type:             schema.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppressEquivalentHostnameDiff,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"password": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"regions": {
+			Type:     schema.TypeSet,
+			Required: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"username": {
-				Type:     schema.TypeString,
-				Required: true,
+		},
+		"username": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		// Optional arguments
+		"accept_self_signed_cert": {
+			Type:             schema.TypeBool,
+			Optional:         true,
+			Default:          false,
+			DiffSuppressFunc: suppressImportedAcceptSelfSignedCertDiff,
+		},
+		"adopt_existing": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"associated_cloud_account_ids": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			// Optional arguments
-			"accept_self_signed_cert": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+		},
+		"create_default_zones": createDefaultZonesSchema(),
+		"dcid": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"delete_default_zones": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"force_delete": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"ignore_region_failures": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"revalidate": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"tags":     cloudAccountTagsSchema(),
+		"tags_map": cloudAccountTagsMapSchema(),
+		"validate_before_create": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"wait_for_enumeration": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		// Computed attributes
+		"cloud_account_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"custom_properties": {
+			Type:     schema.TypeMap,
+			Computed: true,
+		},
+		"enabled_region_ids": {
+			Type:     schema.TypeSet,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"associated_cloud_account_ids": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+		"failed_regions": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"region": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"reason": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
 				},
 			},
-			"dcid": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"tags": tagsSchema(),
-			// Computed attributes
-			"created_at": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"custom_properties": {
-				Type:     schema.TypeMap,
-				Computed: true,
-			},
-			"links": linksSchema(),
-			"org_id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"owner": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"region_ids": {
-				Type:     schema.TypeSet,
-				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
+		},
+		"links": linksSchema(),
+		"org_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"owner": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"region_id_map": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"updated_at": {
-				Type:     schema.TypeString,
-				Computed: true,
+		},
+		"region_ids": {
+			Type:     schema.TypeSet,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
 		},
+		"updated_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"zone_ids": cloudAccountZoneIdsSchema(),
 	}
 }
 
+// resourceCloudAccountVsphereV0 returns the resource of schema version 0, before the attributes added with version 1
+func resourceCloudAccountVsphereV0() *schema.Resource {
+	s := resourceCloudAccountVsphereSchema()
+	for _, key := range cloudAccountVsphereV1Attributes {
+		delete(s, key)
+	}
+	return &schema.Resource{Schema: s}
+}
+
+// cloudAccountVsphereV1Attributes are the attributes added with schema version 1
+var cloudAccountVsphereV1Attributes = []string{"adopt_existing", "cloud_account_type", "create_default_zones", "delete_default_zones", "enabled_region_ids", "failed_regions", "force_delete", "ignore_region_failures", "region_id_map", "revalidate", "tags_map", "validate_before_create", "wait_for_enumeration", "zone_ids"}
+
+// resourceCloudAccountVsphereStateUpgradeV0 sets the attributes added with schema version 1 that can be derived
+// without reading the cloud account, so that the first plan after the upgrade does not show an update for them. The
+// enabled_region_ids and region_id_map attributes are set by the refresh that follows the upgrade.
+func resourceCloudAccountVsphereStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return nil, nil
+	}
+
+	upgraded := map[string]interface{}{
+		"adopt_existing":         false,
+		"cloud_account_type":     cloudAccountTypeVsphere,
+		"create_default_zones":   false,
+		"delete_default_zones":   false,
+		"failed_regions":         []interface{}{},
+		"force_delete":           false,
+		"ignore_region_failures": false,
+		"revalidate":             false,
+		"tags_map":               map[string]interface{}{},
+		"validate_before_create": false,
+		"wait_for_enumeration":   false,
+		"zone_ids":               []interface{}{},
+	}
+	for key, value := range upgraded {
+		if _, ok := rawState[key]; !ok {
+			rawState[key] = value
+		}
+	}
+
+	return rawState, nil
+}
+
 func resourceCloudAccountVsphereCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var regions, associatedCloudAccountIds []string
 
 	apiClient := m.(*Client).apiClient
 
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	tags := expandCloudAccountTags(d)
 	if v, ok := d.GetOk("regions"); ok {
 		if !compareUnique(v.(*schema.Set).List()) {
 			return diag.FromErr(errors.New("specified regions are not unique"))
@@ -122,48 +279,169 @@ func resourceCloudAccountVsphereCreate(ctx context.Context, d *schema.ResourceDa
 		associatedCloudAccountIds = expandStringList(v.(*schema.Set).List())
 	}
 
-	createResp, err := apiClient.CloudAccount.CreateVSphereCloudAccount(
-		cloud_account.NewCreateVSphereCloudAccountParams().
-			WithTimeout(IncreasedTimeOut).
-			WithBody(&models.CloudAccountVsphereSpecification{
-				AcceptSelfSignedCertificate: d.Get("accept_self_signed_cert").(bool),
-				AssociatedCloudAccountIds:   associatedCloudAccountIds,
-				CreateDefaultZones:          false,
-				Dcid:                        d.Get("dcid").(string),
-				Description:                 d.Get("description").(string),
-				HostName:                    withString(d.Get("hostname").(string)),
-				Name:                        withString(d.Get("name").(string)),
-				Password:                    withString(d.Get("password").(string)),
-				RegionIds:                   regions,
-				Tags:                        tags,
-				Username:                    withString(d.Get("username").(string)),
-			}))
+	// The region enumeration connects to the vCenter with the supplied credentials, so it fails fast if the vCenter
+	// is unreachable or the credentials are rejected.
+	if d.Get("validate_before_create").(bool) {
+		if _, err := enumerateCloudAccountVsphereRegions(d, m.(*Client)); err != nil {
+			return diagFromCloudAccountValidationError(err, cloudAccountVsphereAPIFields)
+		}
+	}
+
+	// The cloud account is created without its associated cloud accounts, which are set by a separate update below.
+	// A rejection of that update can then only be caused by the associated cloud accounts.
+	create := func(regionIds []string) (*cloud_account.CreateVSphereCloudAccountCreated, error) {
+		release, err := m.(*Client).acquireRequestSlot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return apiClient.CloudAccount.CreateVSphereCloudAccount(
+			cloud_account.NewCreateVSphereCloudAccountParams().
+				WithTimeout(m.(*Client).requestTimeout(d, resourceCloudAccountVsphere().Timeouts, schema.TimeoutCreate)).
+				WithBody(&models.CloudAccountVsphereSpecification{
+					AcceptSelfSignedCertificate: d.Get("accept_self_signed_cert").(bool),
+					CreateDefaultZones:          d.Get("create_default_zones").(bool),
+					Dcid:                        d.Get("dcid").(string),
+					Description:                 d.Get("description").(string),
+					HostName:                    withString(d.Get("hostname").(string)),
+					Name:                        withString(d.Get("name").(string)),
+					Password:                    withString(d.Get("password").(string)),
+					RegionIds:                   regionIds,
+					Tags:                        tags,
+					Username:                    withString(d.Get("username").(string)),
+				}))
+	}
 
+	var createResp *cloud_account.CreateVSphereCloudAccountCreated
+	var err error
+	var failedRegions []interface{}
+	createdRegions, remainingRegions := regions, []string(nil)
+	if !d.Get("ignore_region_failures").(bool) {
+		createResp, err = create(regions)
+	} else {
+		// The cloud account is created with the first region that the API accepts, the other regions are then
+		// enabled one at a time, so that a region that cannot be enabled does not fail the others
+		for i, region := range regions {
+			createResp, err = create([]string{region})
+			if err == nil {
+				createdRegions, remainingRegions = []string{region}, regions[i+1:]
+				break
+			}
+			if !isBadRequestError(err) {
+				break
+			}
+			log.Printf("[DEBUG] Region %s of cloud account %s was rejected: %s", region, d.Get("name"), redactSecrets(err.Error(), d.Get("password").(string)))
+			failedRegions = append(failedRegions, flattenFailedRegion(region, err, d.Get("password").(string)))
+		}
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		// The API rejects a duplicate name with a generic bad request, so look up whether the name is taken
+		if name := d.Get("name").(string); isBadRequestError(err) {
+			existingID, lookupErr := cloudAccountVsphereIDByName(apiClient, name, m.(*Client).requestTimeout(d, resourceCloudAccountVsphere().Timeouts, schema.TimeoutCreate))
+			if lookupErr != nil {
+				log.Printf("[DEBUG] Unable to look up the vSphere cloud account %s: %s", name, lookupErr)
+			} else if existingID != "" {
+				if d.Get("adopt_existing").(bool) {
+					log.Printf("[INFO] Adopting the existing vSphere cloud account %s (%s)", name, existingID)
+					d.SetId(existingID)
+					return resourceCloudAccountVsphereUpdate(ctx, d, m)
+				}
+				return diag.Errorf("a vSphere cloud account named %q already exists with id %s. Import it with `terraform import` or set adopt_existing to adopt it.", name, existingID)
+			}
+		}
+		return diagFromAPIError(err, cloudAccountVsphereAPIFields)
+	}
+
+	if diags := setCloudAccountVsphereCreated(d, createdRegions, tags, createResp.Payload); diags.HasError() {
+		return diags
+	}
+
+	if len(remainingRegions) != 0 {
+		enabledRegions, rejectedRegions, err := enableCloudAccountVsphereRegions(ctx, m.(*Client), d, schema.TimeoutCreate, createdRegions, remainingRegions)
+		failedRegions = append(failedRegions, rejectedRegions...)
+		if err != nil {
+			return diag.Errorf("cloud account %s was created, but its regions could not be enabled: %s", d.Id(), err)
+		}
+		regions = enabledRegions
 	}
+	d.Set("failed_regions", failedRegions)
+
+	if len(associatedCloudAccountIds) != 0 {
+		spec := expandCloudAccountVsphereUpdateSpecification(d, regions)
+		if err := associateCloudAccountVsphere(ctx, m.(*Client), d, schema.TimeoutCreate, spec, associatedCloudAccountIds); err != nil {
+			return diag.Errorf("cloud account %s was created, but could not be associated with cloud accounts %v: %s", d.Id(), associatedCloudAccountIds, err)
+		}
+	}
+
+	if d.Get("wait_for_enumeration").(bool) {
+		if err := waitForCloudAccountVsphereEnumeration(ctx, m.(*Client), d); err != nil {
+			return diag.Errorf("cloud account %s was created, but its initial data collection did not complete: %s", d.Id(), err)
+		}
+	}
+
+	return resourceCloudAccountVsphereRead(ctx, d, m)
+}
+
+// waitForCloudAccountVsphereEnumeration waits, until the create timeout, for the initial data collection of a new cloud
+// account. The API does not expose the state of the data collection, so it is considered complete once the datastores
+// of the cloud account are collected, which is also when the fabric data sources start returning results.
+func waitForCloudAccountVsphereEnumeration(ctx context.Context, c *Client, d *schema.ResourceData) error {
+	id := d.Id()
+	filter := fmt.Sprintf("cloudAccountId eq '%s'", id)
+
+	err := resource.RetryContext(ctx, operationTimeout(d, schema.TimeoutCreate, cloudAccountVsphereRetryTimeout), func() *resource.RetryError {
+		release, err := c.acquireRequestSlot(ctx)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		getResp, err := c.apiClient.FabricvSphereDatastore.GetFabricVSphereDatastores(
+			fabric_vsphere_datastore.NewGetFabricVSphereDatastoresParams().WithDollarFilter(withString(filter)).WithTimeout(c.apiTimeout))
+		release()
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if len(getResp.Payload.Content) == 0 {
+			log.Printf("[DEBUG] Waiting for the initial data collection of cloud account %s", id)
+			return resource.RetryableError(fmt.Errorf("no datastores of cloud account %s were collected yet", id))
+		}
+		return nil
+	})
+
+	if timeoutErr, ok := err.(*resource.TimeoutError); ok && timeoutErr.LastError != nil {
+		return timeoutErr.LastError
+	}
+	return err
+}
+
+// setCloudAccountVsphereCreated records a newly created cloud account in the state. The id is set first, so that the
+// cloud account is tracked, and destroyed on the next apply, even if one of the following steps fails.
+func setCloudAccountVsphereCreated(d *schema.ResourceData, regions []string, tags []*models.Tag, cloudAccount *models.CloudAccountVsphere) diag.Diagnostics {
+	d.SetId(*cloudAccount.ID)
+	d.Set("enabled_region_ids", cloudAccount.EnabledRegionIds)
 
 	// The returned EnabledRegionIds and Hrefs containing the region ids can be in a different order than the request order.
 	// Call a routine to normalize the order to correspond with the users region order.
-	regionsIds, err := flattenAndNormalizeCloudAccountVsphereRegionIds(regions, createResp.Payload)
+	regionsIds, err := flattenAndNormalizeCloudAccountVsphereRegionIds(regions, cloudAccount)
 	if err != nil {
-		return diag.FromErr(err)
+		d.Partial(true)
+		return diag.Errorf("cloud account %s was created, but its region ids could not be normalized: %s", *cloudAccount.ID, err)
 	}
 	d.Set("region_ids", regionsIds)
+	d.Set("region_id_map", flattenCloudAccountVsphereRegionIDMap(cloudAccount))
 
-	if err := d.Set("tags", flattenTags(tags)); err != nil {
+	if err := setCloudAccountTags(d, tags); err != nil {
+		d.Partial(true)
 		return diag.Errorf("Error setting cloud account tags - error: %#v", err)
 	}
-	d.SetId(*createResp.Payload.ID)
 
-	return resourceCloudAccountVsphereRead(ctx, d, m)
+	return nil
 }
 
 func resourceCloudAccountVsphereRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
-	ret, err := apiClient.CloudAccount.GetVSphereCloudAccount(cloud_account.NewGetVSphereCloudAccountParams().WithID(id))
+	ret, err := apiClient.CloudAccount.GetVSphereCloudAccount(cloud_account.NewGetVSphereCloudAccountParams().WithID(id).WithTimeout(m.(*Client).apiTimeout))
 	if err != nil {
 		switch err.(type) {
 		case *cloud_account.GetVSphereCloudAccountNotFound:
@@ -176,11 +454,17 @@ func resourceCloudAccountVsphereRead(ctx context.Context, d *schema.ResourceData
 	regions := vsphereAccount.EnabledRegionIds
 
 	d.Set("associated_cloud_account_ids", flattenAssociatedCloudAccountIds(vsphereAccount.Links))
+	// The vSphere cloud account payload has no type, it is the cloudAccountType of the generic cloud account API
+	d.Set("cloud_account_type", cloudAccountTypeVsphere)
 	d.Set("created_at", vsphereAccount.CreatedAt)
 	d.Set("custom_properties", vsphereAccount.CustomProperties)
 	d.Set("dcid", vsphereAccount.Dcid)
 	d.Set("description", vsphereAccount.Description)
-	d.Set("hostname", vsphereAccount.HostName)
+	d.Set("enabled_region_ids", vsphereAccount.EnabledRegionIds)
+	// Keep the hostname as configured when the API returns it with a different case or trailing dot
+	if hostname := vsphereAccount.HostName; hostname == nil || !equivalentHostnames(d.Get("hostname").(string), *hostname) {
+		d.Set("hostname", hostname)
+	}
 	d.Set("name", vsphereAccount.Name)
 	d.Set("org_id", vsphereAccount.OrgID)
 	d.Set("owner", vsphereAccount.Owner)
@@ -188,7 +472,7 @@ func resourceCloudAccountVsphereRead(ctx context.Context, d *schema.ResourceData
 	d.Set("updated_at", vsphereAccount.UpdatedAt)
 	d.Set("username", vsphereAccount.Username)
 
-	if err := d.Set("links", flattenLinks(vsphereAccount.Links)); err != nil {
+	if err := d.Set("links", flattenLinks(filterLinks(vsphereAccount.Links, m.(*Client).linksFilter))); err != nil {
 		return diag.Errorf("error setting cloud_account_vsphere links - error: %#v", err)
 	}
 
@@ -199,11 +483,16 @@ func resourceCloudAccountVsphereRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 	d.Set("region_ids", regionsIds)
+	d.Set("region_id_map", flattenCloudAccountVsphereRegionIDMap(&vsphereAccount))
 
-	if err := d.Set("tags", flattenTags(vsphereAccount.Tags)); err != nil {
+	if err := setCloudAccountTags(d, vsphereAccount.Tags); err != nil {
 		return diag.Errorf("Error setting cloud account tags - error: %#v", err)
 	}
 
+	if err := setCloudAccountZoneIds(d, apiClient, m.(*Client).apiTimeout); err != nil {
+		return diag.Errorf("Error setting cloud account zone_ids - error: %#v", err)
+	}
+
 	return nil
 }
 
@@ -220,16 +509,69 @@ func resourceCloudAccountVsphereUpdate(ctx context.Context, d *schema.ResourceDa
 		}
 		regions = expandStringList(v.(*schema.Set).List())
 	}
-	_, err := apiClient.CloudAccount.UpdateVSphereCloudAccount(cloud_account.NewUpdateVSphereCloudAccountParams().WithID(id).WithBody(&models.UpdateCloudAccountVsphereSpecification{
-		CreateDefaultZones: false,
-		Description:        d.Get("description").(string),
-		RegionIds:          regions,
-		Tags:               expandTags(d.Get("tags").(*schema.Set).List()),
-	}))
+
+	// Toggling revalidate checks that the vCenter is reachable with the configured credentials, which are then sent
+	// with the update below even if nothing else changed
+	if d.HasChange("revalidate") {
+		if _, err := enumerateCloudAccountVsphereRegions(d, m.(*Client)); err != nil {
+			d.Partial(true)
+			return diagFromCloudAccountValidationError(err, cloudAccountVsphereAPIFields)
+		}
+	}
+
+	// The regions that were not enabled yet are enabled one at a time after the update, so that a region that cannot
+	// be enabled does not fail the others
+	var addedRegions []string
+	if d.Get("ignore_region_failures").(bool) && d.HasChange("regions") {
+		oldRegions, _ := d.GetChange("regions")
+		enabledRegions := make([]string, 0, len(regions))
+		for _, region := range regions {
+			if oldRegions.(*schema.Set).Contains(region) {
+				enabledRegions = append(enabledRegions, region)
+			} else {
+				addedRegions = append(addedRegions, region)
+			}
+		}
+		regions = enabledRegions
+	}
+
+	updateSpec := expandCloudAccountVsphereUpdateSpecification(d, regions)
+
+	// The credentials are sent when the username or password differs from the state, which includes a username
+	// changed outside of Terraform
+	if d.HasChanges("username", "password", "revalidate") {
+		updateSpec.Username = d.Get("username").(string)
+		updateSpec.Password = d.Get("password").(string)
+	}
+
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.UpdateVSphereCloudAccount(cloud_account.NewUpdateVSphereCloudAccountParams().
+		WithID(id).
+		WithTimeout(m.(*Client).requestTimeout(d, resourceCloudAccountVsphere().Timeouts, schema.TimeoutUpdate)).
+		WithBody(&updateSpec))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	enabledRegions, failedRegions, err := enableCloudAccountVsphereRegions(ctx, m.(*Client), d, schema.TimeoutUpdate, regions, addedRegions)
+	d.Set("failed_regions", failedRegions)
+	if err != nil {
+		return diag.Errorf("error enabling the regions of cloud account %s: %s", id, err)
+	}
+	regions = enabledRegions
+
+	if d.HasChange("associated_cloud_account_ids") {
+		associatedCloudAccountIds := expandStringList(d.Get("associated_cloud_account_ids").(*schema.Set).List())
+		spec := expandCloudAccountVsphereUpdateSpecification(d, regions)
+		if err := associateCloudAccountVsphere(ctx, m.(*Client), d, schema.TimeoutUpdate, spec, associatedCloudAccountIds); err != nil {
+			return diag.Errorf("error associating cloud account %s with cloud accounts %v: %s", id, associatedCloudAccountIds, err)
+		}
+	}
+
 	return resourceCloudAccountVsphereRead(ctx, d, m)
 }
 
@@ -237,7 +579,24 @@ func resourceCloudAccountVsphereDelete(ctx context.Context, d *schema.ResourceDa
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
-	_, err := apiClient.CloudAccount.DeleteVSphereCloudAccount(cloud_account.NewDeleteVSphereCloudAccountParams().WithID(id))
+	timeout := m.(*Client).requestTimeout(d, resourceCloudAccountVsphere().Timeouts, schema.TimeoutDelete)
+
+	if d.Get("force_delete").(bool) {
+		if err := deleteCloudAccountDependents(apiClient, id, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.Get("delete_default_zones").(bool) {
+		if err := deleteCloudAccountZones(apiClient, id, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	release, err := m.(*Client).acquireRequestSlot(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = apiClient.CloudAccount.DeleteVSphereCloudAccount(cloud_account.NewDeleteVSphereCloudAccountParams().WithID(id).WithTimeout(timeout))
+	release()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -246,3 +605,210 @@ func resourceCloudAccountVsphereDelete(ctx context.Context, d *schema.ResourceDa
 
 	return nil
 }
+
+// expandCloudAccountVsphereUpdateSpecification returns the update specification of the configured description, regions
+// and tags. The credentials and associated cloud accounts are left unset, so that they are not changed.
+func expandCloudAccountVsphereUpdateSpecification(d *schema.ResourceData, regions []string) models.UpdateCloudAccountVsphereSpecification {
+	return models.UpdateCloudAccountVsphereSpecification{
+		CreateDefaultZones: false,
+		Description:        d.Get("description").(string),
+		RegionIds:          regions,
+		Tags:               expandCloudAccountTags(d),
+	}
+}
+
+// associateCloudAccountVsphere sets the associated cloud accounts of the cloud account. The API does not expose the
+// connection state of a cloud account, and rejects the association of a cloud account that is not connected yet, for
+// example one created in the same apply. The update is therefore retried while it is rejected, until the timeout of
+// the operation.
+func associateCloudAccountVsphere(ctx context.Context, c *Client, d *schema.ResourceData, timeoutKey string, spec models.UpdateCloudAccountVsphereSpecification, associatedCloudAccountIds []string) error {
+	id := d.Id()
+
+	// An empty list, rather than nil, removes all the associations
+	spec.AssociatedCloudAccountIds = make([]string, 0, len(associatedCloudAccountIds))
+	spec.AssociatedCloudAccountIds = append(spec.AssociatedCloudAccountIds, associatedCloudAccountIds...)
+
+	err := resource.RetryContext(ctx, operationTimeout(d, timeoutKey, cloudAccountVsphereRetryTimeout), func() *resource.RetryError {
+		release, err := c.acquireRequestSlot(ctx)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		_, err = c.apiClient.CloudAccount.UpdateVSphereCloudAccount(cloud_account.NewUpdateVSphereCloudAccountParams().
+			WithID(id).
+			WithTimeout(c.requestTimeout(d, resourceCloudAccountVsphere().Timeouts, timeoutKey)).
+			WithBody(&spec))
+		release()
+		if err != nil {
+			if isBadRequestError(err) {
+				log.Printf("[DEBUG] Association of cloud account %s was rejected, retrying: %s", id, redactSecrets(err.Error(), d.Get("password").(string)))
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+
+	if timeoutErr, ok := err.(*resource.TimeoutError); ok && timeoutErr.LastError != nil {
+		return timeoutErr.LastError
+	}
+	return err
+}
+
+// enableCloudAccountVsphereRegions enables the regions one at a time in addition to the enabled regions, so that a
+// region the API rejects does not prevent the others from being enabled. It returns the enabled regions and the
+// rejected regions with the reason of their rejection.
+func enableCloudAccountVsphereRegions(ctx context.Context, c *Client, d *schema.ResourceData, timeoutKey string, enabledRegions, regions []string) ([]string, []interface{}, error) {
+	var failedRegions []interface{}
+
+	for _, region := range regions {
+		spec := expandCloudAccountVsphereUpdateSpecification(d, append(append([]string{}, enabledRegions...), region))
+
+		release, err := c.acquireRequestSlot(ctx)
+		if err != nil {
+			return enabledRegions, failedRegions, err
+		}
+		_, err = c.apiClient.CloudAccount.UpdateVSphereCloudAccount(cloud_account.NewUpdateVSphereCloudAccountParams().
+			WithID(d.Id()).
+			WithTimeout(c.requestTimeout(d, resourceCloudAccountVsphere().Timeouts, timeoutKey)).
+			WithBody(&spec))
+		release()
+		if err != nil {
+			if !isBadRequestError(err) {
+				return enabledRegions, failedRegions, err
+			}
+			log.Printf("[DEBUG] Region %s of cloud account %s was rejected: %s", region, d.Id(), redactSecrets(err.Error(), d.Get("password").(string)))
+			failedRegions = append(failedRegions, flattenFailedRegion(region, err, d.Get("password").(string)))
+			continue
+		}
+		enabledRegions = append(enabledRegions, region)
+	}
+
+	return enabledRegions, failedRegions, nil
+}
+
+// flattenFailedRegion returns the failed region with the reason of its rejection. Some error responses echo back the
+// request, so the secrets are redacted from the reason.
+func flattenFailedRegion(region string, err error, secrets ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"region": region,
+		"reason": redactSecrets(apiErrorMessage(err), secrets...),
+	}
+}
+
+// cloudAccountVsphereIDByName returns the id of the vSphere cloud account with the name, or an empty string if there
+// is none
+func cloudAccountVsphereIDByName(apiClient *client.MulticloudIaaS, name string, timeout time.Duration) (string, error) {
+	for skip := 0; ; skip += cloudAccountsPageSize {
+		getResp, err := apiClient.CloudAccount.GetVSphereCloudAccounts(cloud_account.NewGetVSphereCloudAccountsParams().WithTimeout(timeout), withPage(skip, cloudAccountsPageSize))
+		if err != nil {
+			return "", err
+		}
+
+		page := getResp.Payload.Content
+		for _, account := range page {
+			if account.ID != nil && account.Name == name {
+				return *account.ID, nil
+			}
+		}
+
+		if len(page) < cloudAccountsPageSize {
+			return "", nil
+		}
+	}
+}
+
+// resourceCloudAccountVsphereImport sets the arguments that only exist in Terraform to their defaults, so that a
+// plan following the import does not show an update for them. accept_self_signed_cert is left unset, since the API
+// does not return it and any default could contradict the configuration.
+func resourceCloudAccountVsphereImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("adopt_existing", false)
+	d.Set("create_default_zones", false)
+	d.Set("delete_default_zones", false)
+	d.Set("force_delete", false)
+	d.Set("ignore_region_failures", false)
+	d.Set("revalidate", false)
+	d.Set("validate_before_create", false)
+	d.Set("wait_for_enumeration", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// suppressImportedAcceptSelfSignedCertDiff suppresses the diff of accept_self_signed_cert of an imported cloud account,
+// which has no value in the state. The argument is only sent when connecting to the vCenter, so the configured value is
+// kept out of the plan until the next revalidation, which needs it.
+func suppressImportedAcceptSelfSignedCertDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == "" && !d.HasChange("revalidate")
+}
+
+// enumerateCloudAccountVsphereRegions connects to the vCenter with the configured credentials and returns its regions
+func enumerateCloudAccountVsphereRegions(d resourceGetter, c *Client) ([]string, error) {
+	getResp, err := c.apiClient.CloudAccount.EnumerateVSphereRegions(
+		cloud_account.NewEnumerateVSphereRegionsParams().
+			WithTimeout(c.apiTimeout).
+			WithBody(&models.CloudAccountVsphereSpecification{
+				AcceptSelfSignedCertificate: d.Get("accept_self_signed_cert").(bool),
+				Dcid:                        d.Get("dcid").(string),
+				HostName:                    withString(d.Get("hostname").(string)),
+				Password:                    withString(d.Get("password").(string)),
+				Username:                    withString(d.Get("username").(string)),
+			}))
+	if err != nil {
+		return nil, err
+	}
+
+	return getResp.Payload.ExternalRegionIds, nil
+}
+
+// resourceCloudAccountVsphereCustomizeDiff verifies that the requested regions can be discovered on the vCenter.
+// The check connects to the vCenter, so it only runs when validate_before_create is set, for a new cloud account or a
+// change of its regions. It is best effort and is skipped when the credentials are not known yet or the enumeration
+// fails.
+func resourceCloudAccountVsphereCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if (d.Id() == "" || d.HasChange("associated_cloud_account_ids")) && d.NewValueKnown("associated_cloud_account_ids") {
+		if v, ok := d.GetOk("associated_cloud_account_ids"); ok {
+			if err := validateCloudAccountsExist(m.(*Client), expandStringList(v.(*schema.Set).List())); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !d.Get("validate_before_create").(bool) || (d.Id() != "" && !d.HasChange("regions")) {
+		return nil
+	}
+
+	for _, key := range []string{"accept_self_signed_cert", "dcid", "hostname", "password", "regions", "username"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	discoverable, err := enumerateCloudAccountVsphereRegions(d, m.(*Client))
+	if err != nil {
+		log.Printf("[WARN] Skipping validation of regions, unable to enumerate the regions of %s: %s", d.Get("hostname"), redactSecrets(err.Error(), d.Get("password").(string)))
+		return nil
+	}
+
+	return validateRegionsDiscoverable(expandStringList(d.Get("regions").(*schema.Set).List()), discoverable)
+}
+
+// validateCloudAccountsExist will return an error listing the cloud accounts that do not exist. Cloud accounts that
+// cannot be looked up for another reason are not reported, the API then reports them when they are used.
+func validateCloudAccountsExist(c *Client, ids []string) error {
+	var missing []string
+	for _, id := range ids {
+		_, err := c.apiClient.CloudAccount.GetCloudAccount(cloud_account.NewGetCloudAccountParams().WithID(id).WithTimeout(c.apiTimeout))
+		if err != nil {
+			if _, ok := err.(*cloud_account.GetCloudAccountNotFound); ok {
+				missing = append(missing, id)
+				continue
+			}
+			log.Printf("[WARN] Skipping validation of cloud account %s, unable to look it up: %s", id, err)
+		}
+	}
+
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("associated_cloud_account_ids: cloud accounts %s do not exist", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -1,20 +1,78 @@
 package vra
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
 	"os"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
+	"github.com/vmware/vra-sdk-go/pkg/client/location"
+	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
+func init() {
+	resource.AddTestSweepers("vra_cloud_account_vsphere", &resource.Sweeper{
+		Name: "vra_cloud_account_vsphere",
+		F:    testSweepCloudAccountVsphere,
+	})
+}
+
+func testSweepCloudAccountVsphere(region string) error {
+	c, err := sharedClientForSweepers()
+	if err != nil {
+		return err
+	}
+	apiClient := c.apiClient
+
+	// Collect the accounts from every page first, deleting while paging would shift the offsets
+	accounts := make(map[string]string)
+	for skip := 0; ; skip += sweeperPageSize {
+		getResp, err := apiClient.CloudAccount.GetVSphereCloudAccounts(cloud_account.NewGetVSphereCloudAccountsParams(), withPage(skip, sweeperPageSize))
+		if err != nil {
+			return fmt.Errorf("error listing vSphere cloud accounts: %s", err)
+		}
+
+		page := getResp.Payload.Content
+		for _, account := range page {
+			if account.ID != nil && isSweepable(account.Name) {
+				accounts[*account.ID] = account.Name
+			}
+		}
+
+		if len(page) < sweeperPageSize {
+			break
+		}
+	}
+
+	for id, name := range accounts {
+		log.Printf("[INFO] Deleting vSphere cloud account %s (%s)", name, id)
+		_, err := apiClient.CloudAccount.DeleteVSphereCloudAccount(cloud_account.NewDeleteVSphereCloudAccountParams().WithID(id))
+		if err != nil {
+			log.Printf("[ERROR] Failed to delete vSphere cloud account %s: %s", id, err)
+		}
+	}
+
+	return nil
+}
+
 func TestAccVRACloudAccountvSphere_Basic(t *testing.T) {
 	rInt := acctest.RandInt()
+	var state terraform.InstanceState
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheckVsphere(t) },
@@ -31,12 +89,334 @@ func TestAccVRACloudAccountvSphere_Basic(t *testing.T) {
 						"vra_cloud_account_vsphere.my_vsphere_account", "description", "test cloud account"),
 					resource.TestCheckResourceAttr(
 						"vra_cloud_account_vsphere.my_vsphere_account", "tags.#", "2"),
+					resource.TestCheckResourceAttrSet(
+						"vra_cloud_account_vsphere.my_vsphere_account", "enabled_region_ids.#"),
+				),
+			},
+			{
+				Config: testAccCheckVRACloudAccountvSphereNoTagsConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "tags.#", "0"),
+					testAccCheckVRACloudAccountvSphereNoTags("vra_cloud_account_vsphere.my_vsphere_account"),
+					testAccCheckVRACloudAccountvSphereState("vra_cloud_account_vsphere.my_vsphere_account", &state),
+				),
+			},
+			{
+				// The imported region_ids must match the ones normalized at create, whatever order the API returns
+				ResourceName:            "vra_cloud_account_vsphere.my_vsphere_account",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"accept_self_signed_cert", "password"},
+			},
+			{
+				// The test framework does not keep the imported state for a following PlanOnly step, so the imported
+				// state is planned against the applied configuration here
+				ResourceName:     "vra_cloud_account_vsphere.my_vsphere_account",
+				ImportState:      true,
+				ImportStateCheck: testAccCheckVRACloudAccountvSphereImportPlan(&state),
+			},
+		},
+	})
+}
+
+func TestAccVRACloudAccountvSphere_Revalidate(t *testing.T) {
+	rInt := acctest.RandInt()
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckVsphere(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRACloudAccountvSphereDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRACloudAccountvSphereConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRACloudAccountvSphereID("vra_cloud_account_vsphere.my_vsphere_account", &id),
+					resource.TestCheckResourceAttr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "revalidate", "false"),
+				),
+			},
+			{
+				Config: testAccCheckVRACloudAccountvSphereRevalidateConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "id", &id),
+					resource.TestCheckResourceAttr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "revalidate", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVRACloudAccountvSphere_ForceDelete(t *testing.T) {
+	rInt := acctest.RandInt()
+	var zoneID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckVsphere(t) },
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckVRACloudAccountvSphereDestroy,
+			testAccCheckVRAZoneDeleted(&zoneID),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRACloudAccountvSphereForceDeleteConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRACloudAccountvSphereExists("vra_cloud_account_vsphere.my_vsphere_account"),
+					resource.TestCheckResourceAttr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "force_delete", "true"),
+					// A zone created outside of Terraform must be deleted along with the cloud account
+					testAccVRACloudAccountvSphereCreateZone("vra_cloud_account_vsphere.my_vsphere_account", rInt, &zoneID),
+				),
+			},
+		},
+	})
+}
+
+// testAccVRACloudAccountvSphereCreateZone creates a zone in the first region of the cloud account directly through the API
+func testAccVRACloudAccountvSphereCreateZone(n string, rInt int, zoneID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		var regionID string
+		for key, value := range rs.Primary.Attributes {
+			if strings.HasPrefix(key, "region_ids.") && key != "region_ids.#" {
+				regionID = value
+				break
+			}
+		}
+		if regionID == "" {
+			return fmt.Errorf("no region ids are set on %s", n)
+		}
+
+		apiClient := testAccProviderVRA.Meta().(*Client).apiClient
+		createResp, err := apiClient.Location.CreateZone(location.NewCreateZoneParams().WithBody(&models.ZoneSpecification{
+			Name:     withString(fmt.Sprintf("my_vsphere_account_zone_%d", rInt)),
+			RegionID: withString(regionID),
+		}))
+		if err != nil {
+			return err
+		}
+
+		*zoneID = *createResp.Payload.ID
+
+		return nil
+	}
+}
+
+func testAccCheckVRAZoneDeleted(zoneID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if *zoneID == "" {
+			return nil
+		}
+
+		apiClient := testAccProviderVRA.Meta().(*Client).apiClient
+		_, err := apiClient.Location.GetZone(location.NewGetZoneParams().WithID(*zoneID))
+		if err == nil {
+			return fmt.Errorf("zone %s still exists", *zoneID)
+		}
+
+		return nil
+	}
+}
+
+func TestAccVRACloudAccountvSphere_ValidateBeforeCreate(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckVsphere(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRACloudAccountvSphereDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckVRACloudAccountvSphereValidateBeforeCreateConfig(rInt),
+				ExpectError: regexp.MustCompile("(?i)password|username|credential|authenticat"),
+			},
+		},
+	})
+}
+
+func TestAccVRACloudAccountvSphere_OutOfBandDescription(t *testing.T) {
+	rInt := acctest.RandInt()
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckVsphere(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRACloudAccountvSphereDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRACloudAccountvSphereConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRACloudAccountvSphereExists("vra_cloud_account_vsphere.my_vsphere_account"),
+					testAccCheckVRACloudAccountvSphereID("vra_cloud_account_vsphere.my_vsphere_account", &id),
+				),
+			},
+			{
+				PreConfig: func() {
+					testAccVRACloudAccountvSphereUpdateOutOfBand(t, id, func(spec *models.UpdateCloudAccountVsphereSpecification) {
+						spec.Description = "changed outside of terraform"
+					})
+				},
+				Config: testAccCheckVRACloudAccountvSphereConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "description", "test cloud account"),
+					resource.TestCheckResourceAttrPtr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "id", &id),
+				),
+			},
+			{
+				Config: testAccCheckVRACloudAccountvSphereNoDescriptionConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "description", ""),
+					resource.TestCheckResourceAttrPtr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "id", &id),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVRACloudAccountvSphere_OutOfBandUsername(t *testing.T) {
+	rInt := acctest.RandInt()
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckVsphereAlternateCredentials(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRACloudAccountvSphereDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRACloudAccountvSphereConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRACloudAccountvSphereExists("vra_cloud_account_vsphere.my_vsphere_account"),
+					testAccCheckVRACloudAccountvSphereID("vra_cloud_account_vsphere.my_vsphere_account", &id),
+				),
+			},
+			{
+				PreConfig: func() {
+					testAccVRACloudAccountvSphereUpdateOutOfBand(t, id, func(spec *models.UpdateCloudAccountVsphereSpecification) {
+						spec.Username = os.Getenv("VRA_VSPHERE_ALTERNATE_USERNAME")
+						spec.Password = os.Getenv("VRA_VSPHERE_ALTERNATE_PASSWORD")
+					})
+				},
+				Config: testAccCheckVRACloudAccountvSphereConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "username", os.Getenv("VRA_VSPHERE_USERNAME")),
+					resource.TestCheckResourceAttrPtr(
+						"vra_cloud_account_vsphere.my_vsphere_account", "id", &id),
 				),
 			},
+			{
+				// The cloud account converged, so the same configuration plans no changes
+				Config:   testAccCheckVRACloudAccountvSphereConfig(rInt),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
+func testAccCheckVRACloudAccountvSphereID(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		*id = rs.Primary.ID
+
+		return nil
+	}
+}
+
+func testAccCheckVRACloudAccountvSphereState(n string, state *terraform.InstanceState) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		*state = *rs.Primary.DeepCopy()
+
+		return nil
+	}
+}
+
+// testAccCheckVRACloudAccountvSphereImportPlan plans the imported cloud account against the arguments of the applied
+// state and fails if any argument other than the password, which the API does not return, would change
+func testAccCheckVRACloudAccountvSphereImportPlan(state *terraform.InstanceState) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		r := resourceCloudAccountVsphere()
+		applied := r.Data(state)
+
+		raw := make(map[string]interface{})
+		for key, s := range r.Schema {
+			if !s.Optional && !s.Required {
+				continue
+			}
+			if v, ok := applied.GetOk(key); ok {
+				if set, ok := v.(*schema.Set); ok {
+					v = set.List()
+				}
+				raw[key] = v
+			}
+		}
+
+		for _, is := range states {
+			if is.Ephemeral.Type != "vra_cloud_account_vsphere" {
+				continue
+			}
+			diff, err := r.Diff(context.Background(), is, terraform.NewResourceConfigRaw(raw), testAccProviderVRA.Meta())
+			if err != nil {
+				return err
+			}
+			if diff == nil {
+				return nil
+			}
+			for key, attrDiff := range diff.Attributes {
+				if key != "password" && !attrDiff.NewComputed {
+					return fmt.Errorf("expected no change of %s after import, got %#v", key, attrDiff)
+				}
+			}
+			return nil
+		}
+
+		return fmt.Errorf("vra_cloud_account_vsphere was not imported")
+	}
+}
+
+// testAccVRACloudAccountvSphereUpdateOutOfBand updates the cloud account directly through the API, starting from its
+// current description, regions and tags
+func testAccVRACloudAccountvSphereUpdateOutOfBand(t *testing.T, id string, update func(*models.UpdateCloudAccountVsphereSpecification)) {
+	apiClient := testAccProviderVRA.Meta().(*Client).apiClient
+
+	ret, err := apiClient.CloudAccount.GetVSphereCloudAccount(cloud_account.NewGetVSphereCloudAccountParams().WithID(id))
+	if err != nil {
+		t.Fatalf("error reading cloud account %s: %s", id, err)
+	}
+
+	spec := models.UpdateCloudAccountVsphereSpecification{
+		Description: ret.Payload.Description,
+		RegionIds:   ret.Payload.EnabledRegionIds,
+		Tags:        ret.Payload.Tags,
+	}
+	update(&spec)
+
+	_, err = apiClient.CloudAccount.UpdateVSphereCloudAccount(cloud_account.NewUpdateVSphereCloudAccountParams().WithID(id).WithBody(&spec))
+	if err != nil {
+		t.Fatalf("error updating cloud account %s: %s", id, err)
+	}
+}
+
 func testAccCheckVRACloudAccountvSphereExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -52,6 +432,27 @@ func testAccCheckVRACloudAccountvSphereExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckVRACloudAccountvSphereNoTags(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		apiClient := testAccProviderVRA.Meta().(*Client).apiClient
+		ret, err := apiClient.CloudAccount.GetVSphereCloudAccount(cloud_account.NewGetVSphereCloudAccountParams().WithID(rs.Primary.ID))
+		if err != nil {
+			return err
+		}
+
+		if len(ret.Payload.Tags) != 0 {
+			return fmt.Errorf("expected no tags on cloud account %s, got %d", rs.Primary.ID, len(ret.Payload.Tags))
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckVRACloudAccountvSphereDestroy(s *terraform.State) error {
 	apiClient := testAccProviderVRA.Meta().(*Client).apiClient
 
@@ -107,3 +508,765 @@ func testAccCheckVRACloudAccountvSphereConfig(rInt int) string {
 	  }
 	}`, dcname, username, password, hostname, rInt, username, password, hostname)
 }
+
+func testAccCheckVRACloudAccountvSphereNoDescriptionConfig(rInt int) string {
+	// Need valid credentials since this is creating a real cloud account
+	username := os.Getenv("VRA_VSPHERE_USERNAME")
+	password := os.Getenv("VRA_VSPHERE_PASSWORD")
+	hostname := os.Getenv("VRA_VSPHERE_HOSTNAME")
+	dcname := os.Getenv("VRA_VSPHERE_DATACOLLECTOR_NAME")
+	return fmt.Sprintf(`
+	data "vra_data_collector" "dc" {
+		name = "%s"
+	}
+
+	data "vra_region_enumeration" "dc_regions" {
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+	}
+
+	resource "vra_cloud_account_vsphere" "my_vsphere_account" {
+	  name        = "my_vsphere_account_%d"
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+
+	  regions                 = data.vra_region_enumeration.dc_regions.regions
+	  accept_self_signed_cert = true
+	  tags {
+		key   = "foo"
+		value = "bar"
+	  }
+	  tags {
+		key = "where"
+		value = "waldo"
+	  }
+	}`, dcname, username, password, hostname, rInt, username, password, hostname)
+}
+
+func testAccCheckVRACloudAccountvSphereNoTagsConfig(rInt int) string {
+	// Need valid credentials since this is creating a real cloud account
+	username := os.Getenv("VRA_VSPHERE_USERNAME")
+	password := os.Getenv("VRA_VSPHERE_PASSWORD")
+	hostname := os.Getenv("VRA_VSPHERE_HOSTNAME")
+	dcname := os.Getenv("VRA_VSPHERE_DATACOLLECTOR_NAME")
+	return fmt.Sprintf(`
+	data "vra_data_collector" "dc" {
+		name = "%s"
+	}
+
+	data "vra_region_enumeration" "dc_regions" {
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+	}
+
+	resource "vra_cloud_account_vsphere" "my_vsphere_account" {
+	  name        = "my_vsphere_account_%d"
+	  description = "test cloud account"
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+
+	  regions                 = data.vra_region_enumeration.dc_regions.regions
+	  accept_self_signed_cert = true
+	}`, dcname, username, password, hostname, rInt, username, password, hostname)
+}
+
+func testAccCheckVRACloudAccountvSphereRevalidateConfig(rInt int) string {
+	// Need valid credentials since this is creating a real cloud account
+	username := os.Getenv("VRA_VSPHERE_USERNAME")
+	password := os.Getenv("VRA_VSPHERE_PASSWORD")
+	hostname := os.Getenv("VRA_VSPHERE_HOSTNAME")
+	dcname := os.Getenv("VRA_VSPHERE_DATACOLLECTOR_NAME")
+	return fmt.Sprintf(`
+	data "vra_data_collector" "dc" {
+		name = "%s"
+	}
+
+	data "vra_region_enumeration" "dc_regions" {
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+	}
+
+	resource "vra_cloud_account_vsphere" "my_vsphere_account" {
+	  name        = "my_vsphere_account_%d"
+	  description = "test cloud account"
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+
+	  regions                 = data.vra_region_enumeration.dc_regions.regions
+	  accept_self_signed_cert = true
+	  revalidate              = true
+	  tags {
+		key   = "foo"
+		value = "bar"
+	  }
+	  tags {
+		key = "where"
+		value = "waldo"
+	  }
+	}`, dcname, username, password, hostname, rInt, username, password, hostname)
+}
+
+func testAccCheckVRACloudAccountvSphereForceDeleteConfig(rInt int) string {
+	// Need valid credentials since this is creating a real cloud account
+	username := os.Getenv("VRA_VSPHERE_USERNAME")
+	password := os.Getenv("VRA_VSPHERE_PASSWORD")
+	hostname := os.Getenv("VRA_VSPHERE_HOSTNAME")
+	dcname := os.Getenv("VRA_VSPHERE_DATACOLLECTOR_NAME")
+	return fmt.Sprintf(`
+	data "vra_data_collector" "dc" {
+		name = "%s"
+	}
+
+	data "vra_region_enumeration" "dc_regions" {
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+	}
+
+	resource "vra_cloud_account_vsphere" "my_vsphere_account" {
+	  name        = "my_vsphere_account_%d"
+	  description = "test cloud account"
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+
+	  regions                 = data.vra_region_enumeration.dc_regions.regions
+	  accept_self_signed_cert = true
+	  force_delete            = true
+	}`, dcname, username, password, hostname, rInt, username, password, hostname)
+}
+
+func testAccCheckVRACloudAccountvSphereValidateBeforeCreateConfig(rInt int) string {
+	// Valid credentials are needed to enumerate the regions, the cloud account then uses a wrong password
+	username := os.Getenv("VRA_VSPHERE_USERNAME")
+	password := os.Getenv("VRA_VSPHERE_PASSWORD")
+	hostname := os.Getenv("VRA_VSPHERE_HOSTNAME")
+	dcname := os.Getenv("VRA_VSPHERE_DATACOLLECTOR_NAME")
+	return fmt.Sprintf(`
+	data "vra_data_collector" "dc" {
+		name = "%s"
+	}
+
+	data "vra_region_enumeration" "dc_regions" {
+	  username    = "%s"
+	  password    = "%s"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+	}
+
+	resource "vra_cloud_account_vsphere" "my_vsphere_account" {
+	  name        = "my_vsphere_account_%d"
+	  description = "test cloud account"
+	  username    = "%s"
+	  password    = "not-the-password-%d"
+	  hostname    = "%s"
+	  dcid        = data.vra_data_collector.dc.id
+
+	  regions                 = data.vra_region_enumeration.dc_regions.regions
+	  accept_self_signed_cert = true
+	  validate_before_create  = true
+	}`, dcname, username, password, hostname, rInt, username, rInt, hostname)
+}
+
+func TestSetCloudAccountVsphereCreated(t *testing.T) {
+	cloudAccount := &models.CloudAccountVsphere{
+		ID:               withString("9e49e0c3-0d6e-4a4d-8b21-0c7a6b0b3d11"),
+		EnabledRegionIds: []string{"Datacenter:datacenter-2"},
+		Links: map[string]models.Href{
+			"regions": {Hrefs: []string{"/iaas/api/regions/f2d1b6a5"}},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudAccountVsphere().Schema, map[string]interface{}{})
+	if diags := setCloudAccountVsphereCreated(d, []string{"Datacenter:datacenter-2"}, nil, cloudAccount); diags.HasError() {
+		t.Fatalf("unexpected error: %#v", diags)
+	}
+	if d.Id() != *cloudAccount.ID {
+		t.Errorf("expected id %s, got %s", *cloudAccount.ID, d.Id())
+	}
+	if regionIds := d.Get("region_ids").(*schema.Set); regionIds.Len() != 1 || !regionIds.Contains("f2d1b6a5") {
+		t.Errorf("region ids are not set correctly: %#v", regionIds.List())
+	}
+
+	// A region that is missing from the response makes the normalization fail
+	d = schema.TestResourceDataRaw(t, resourceCloudAccountVsphere().Schema, map[string]interface{}{})
+	diags := setCloudAccountVsphereCreated(d, []string{"Datacenter:datacenter-3"}, nil, cloudAccount)
+	if !diags.HasError() {
+		t.Fatalf("expected an error when the region ids cannot be normalized")
+	}
+	if d.Id() != *cloudAccount.ID {
+		t.Errorf("expected id %s to be set despite the error, got %q", *cloudAccount.ID, d.Id())
+	}
+}
+
+func TestResourceCloudAccountVsphere_CRUD(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceCloudAccountVsphere()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                         "vsphere",
+		"description":                  "test cloud account",
+		"hostname":                     "vc.example.com",
+		"username":                     "administrator@vsphere.local",
+		"password":                     "secret",
+		"regions":                      []interface{}{"Datacenter:datacenter-2", "Datacenter:datacenter-3"},
+		"associated_cloud_account_ids": []interface{}{"nsxt-1"},
+	})
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned errors %#v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("expected the id to be set")
+	}
+
+	regionIDMap := d.Get("region_id_map").(map[string]interface{})
+	if regionIDMap["Datacenter:datacenter-2"] != "region-datacenter-2" || regionIDMap["Datacenter:datacenter-3"] != "region-datacenter-3" {
+		t.Errorf("region ids are not normalized to the requested regions: %v", regionIDMap)
+	}
+	if ids := d.Get("associated_cloud_account_ids").(*schema.Set); ids.Len() != 1 || !ids.Contains("nsxt-1") {
+		t.Errorf("associated cloud account ids are not set correctly: %v", ids.List())
+	}
+	if d.Get("cloud_account_type") != cloudAccountTypeVsphere {
+		t.Errorf("expected cloud_account_type %q, got %q", cloudAccountTypeVsphere, d.Get("cloud_account_type"))
+	}
+
+	d.Set("description", "updated")
+	if diags := r.UpdateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("update returned errors %#v", diags)
+	}
+	if d.Get("description") != "updated" {
+		t.Errorf("expected the description to be updated, got %q", d.Get("description"))
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("delete returned errors %#v", diags)
+	}
+
+	// A cloud account deleted outside of Terraform is removed from the state
+	d.SetId("cloud-account-1")
+	if diags := r.ReadContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read returned errors %#v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the id of a deleted cloud account to be cleared, got %q", d.Id())
+	}
+
+	// The update also sends the associated cloud account ids, as the test resource data has no prior state
+	expected := []string{
+		"POST /iaas/api/cloud-accounts-vsphere",
+		"PATCH /iaas/api/cloud-accounts-vsphere/cloud-account-1",
+		"GET /iaas/api/cloud-accounts-vsphere/cloud-account-1",
+		"PATCH /iaas/api/cloud-accounts-vsphere/cloud-account-1",
+		"PATCH /iaas/api/cloud-accounts-vsphere/cloud-account-1",
+		"GET /iaas/api/cloud-accounts-vsphere/cloud-account-1",
+		"DELETE /iaas/api/cloud-accounts-vsphere/cloud-account-1",
+		"GET /iaas/api/cloud-accounts-vsphere/cloud-account-1",
+	}
+	if received := api.received(); strings.Join(received, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected the requests %v, got %v", expected, received)
+	}
+}
+
+func TestResourceCloudAccountVsphere_IgnoreRegionFailures(t *testing.T) {
+	const unreachable = "Datacenter:datacenter-9"
+
+	for _, ignoreRegionFailures := range []bool{false, true} {
+		api := newTestMockAPI(t)
+
+		// The API rejects any request that enables the unreachable region
+		rejectUnreachable := func(next http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if strings.Contains(string(body), unreachable) {
+					api.writeError(w, http.StatusBadRequest, "Unable to connect to datacenter-9")
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				next(w, r)
+			}
+		}
+		api.handle(http.MethodPost, "/iaas/api/cloud-accounts-vsphere", rejectUnreachable(api.createCloudAccountVsphere))
+		api.handle(http.MethodPatch, "/iaas/api/cloud-accounts-vsphere/", rejectUnreachable(api.updateCloudAccountVsphere))
+
+		r := resourceCloudAccountVsphere()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"name":                   "vsphere",
+			"hostname":               "vc.example.com",
+			"username":               "administrator@vsphere.local",
+			"password":               "secret",
+			"regions":                []interface{}{unreachable, "Datacenter:datacenter-2", "Datacenter:datacenter-3"},
+			"ignore_region_failures": ignoreRegionFailures,
+		})
+		diags := r.CreateContext(context.Background(), d, api.client())
+
+		if !ignoreRegionFailures {
+			if !diags.HasError() || d.Id() != "" {
+				t.Errorf("expected the creation to fail when a region is rejected, got id %q", d.Id())
+			}
+			continue
+		}
+
+		if diags.HasError() {
+			t.Fatalf("create returned errors %#v", diags)
+		}
+		regionIDMap := d.Get("region_id_map").(map[string]interface{})
+		if len(regionIDMap) != 2 || regionIDMap["Datacenter:datacenter-2"] == nil || regionIDMap["Datacenter:datacenter-3"] == nil {
+			t.Errorf("expected the other regions to be enabled, got %v", regionIDMap)
+		}
+		failedRegions := d.Get("failed_regions").([]interface{})
+		if len(failedRegions) != 1 {
+			t.Fatalf("expected a single failed region, got %v", failedRegions)
+		}
+		failedRegion := failedRegions[0].(map[string]interface{})
+		if failedRegion["region"] != unreachable || failedRegion["reason"] == "" {
+			t.Errorf("unexpected failed region %v", failedRegion)
+		}
+	}
+}
+
+func TestResourceCloudAccountVsphere_DuplicateName(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceCloudAccountVsphere()
+
+	config := map[string]interface{}{
+		"name":     "vsphere",
+		"hostname": "vc.example.com",
+		"username": "administrator@vsphere.local",
+		"password": "secret",
+		"regions":  []interface{}{"Datacenter:datacenter-2"},
+	}
+	existing := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), existing, c); diags.HasError() {
+		t.Fatalf("create returned errors %#v", diags)
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	diags := r.CreateContext(context.Background(), d, c)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "already exists with id "+existing.Id()) {
+		t.Errorf("expected a diagnostic naming the existing cloud account, got %#v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the id not to be set, got %q", d.Id())
+	}
+
+	config["adopt_existing"] = true
+	config["description"] = "adopted"
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned errors %#v", diags)
+	}
+	if d.Id() != existing.Id() {
+		t.Errorf("expected the existing cloud account %s to be adopted, got %q", existing.Id(), d.Id())
+	}
+	if d.Get("description") != "adopted" {
+		t.Errorf("expected the adopted cloud account to be updated, got description %q", d.Get("description"))
+	}
+}
+
+func TestValidateCloudAccountsExist(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceCloudAccountVsphere()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":     "nsxt",
+		"hostname": "vc.example.com",
+		"username": "administrator@vsphere.local",
+		"password": "secret",
+		"regions":  []interface{}{"Datacenter:datacenter-2"},
+	})
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned errors %#v", diags)
+	}
+
+	if err := validateCloudAccountsExist(c, []string{d.Id()}); err != nil {
+		t.Errorf("expected an existing cloud account to be valid, got %s", err)
+	}
+
+	err := validateCloudAccountsExist(c, []string{"deleted", d.Id(), "typo"})
+	if err == nil || err.Error() != "associated_cloud_account_ids: cloud accounts deleted, typo do not exist" {
+		t.Errorf("expected an error listing the missing cloud accounts, got %v", err)
+	}
+}
+
+func TestResourceCloudAccountVsphereStateUpgradeV0(t *testing.T) {
+	// The attributes of the schema released as version 0
+	v0Attributes := make(map[string]bool)
+	for _, key := range []string{"accept_self_signed_cert", "associated_cloud_account_ids", "created_at", "custom_properties", "dcid",
+		"description", "hostname", "links", "name", "org_id", "owner", "password", "region_ids", "regions", "tags", "updated_at", "username"} {
+		v0Attributes[key] = true
+	}
+
+	current := resourceCloudAccountVsphereSchema()
+	var added []string
+	for key := range current {
+		if !v0Attributes[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	if !reflect.DeepEqual(added, cloudAccountVsphereV1Attributes) {
+		t.Errorf("expected the attributes added since version 0 %v to be listed, got %v", added, cloudAccountVsphereV1Attributes)
+	}
+
+	v0 := resourceCloudAccountVsphereV0().Schema
+	for key := range v0 {
+		if !v0Attributes[key] {
+			t.Errorf("expected %s not to be in the version 0 schema", key)
+		}
+	}
+
+	rawState := map[string]interface{}{
+		"id":       "cloud-account-1",
+		"name":     "vsphere",
+		"hostname": "vc.example.com",
+		"regions":  []interface{}{"Datacenter:datacenter-2"},
+	}
+	upgraded, err := resourceCloudAccountVsphereStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("state upgrade returned error %s", err)
+	}
+
+	for key, value := range map[string]interface{}{
+		"id":                 "cloud-account-1",
+		"name":               "vsphere",
+		"hostname":           "vc.example.com",
+		"regions":            []interface{}{"Datacenter:datacenter-2"},
+		"cloud_account_type": "vsphere",
+		"failed_regions":     []interface{}{},
+		"tags_map":           map[string]interface{}{},
+		"zone_ids":           []interface{}{},
+	} {
+		if !reflect.DeepEqual(upgraded[key], value) {
+			t.Errorf("expected %s to be %v in the upgraded state, got %v", key, value, upgraded[key])
+		}
+	}
+
+	// Every added argument with a default is set to its default, so that the first plan does not update it
+	for _, key := range added {
+		if current[key].Default == nil {
+			continue
+		}
+		if !reflect.DeepEqual(upgraded[key], current[key].Default) {
+			t.Errorf("expected %s to be upgraded to its default %v, got %v", key, current[key].Default, upgraded[key])
+		}
+	}
+
+	// Attributes already in the state are kept
+	upgraded, err = resourceCloudAccountVsphereStateUpgradeV0(context.Background(), map[string]interface{}{"ignore_region_failures": true}, nil)
+	if err != nil {
+		t.Fatalf("state upgrade returned error %s", err)
+	}
+	if upgraded["ignore_region_failures"] != true {
+		t.Errorf("expected ignore_region_failures to be kept, got %v", upgraded["ignore_region_failures"])
+	}
+}
+
+func TestResourceCloudAccountVsphere_DeleteDefaultZones(t *testing.T) {
+	for _, deleteDefaultZones := range []bool{false, true} {
+		api := newTestMockAPI(t)
+		c := api.client()
+		r := resourceCloudAccountVsphere()
+
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"name":                 "vsphere",
+			"hostname":             "vc.example.com",
+			"username":             "administrator@vsphere.local",
+			"password":             "secret",
+			"regions":              []interface{}{"Datacenter:datacenter-2"},
+			"delete_default_zones": deleteDefaultZones,
+		})
+		if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("create returned errors %#v", diags)
+		}
+
+		zones := []interface{}{
+			map[string]interface{}{"id": "zone-1", "name": "datacenter-2", "cloudAccountId": d.Id()},
+			map[string]interface{}{"id": "zone-2", "name": "other", "cloudAccountId": "cloud-account-other"},
+		}
+		api.handle(http.MethodGet, "/iaas/api/zones", func(w http.ResponseWriter, r *http.Request) {
+			api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": mockAPIPage(zones, r), "totalElements": len(zones)})
+		})
+		api.handle(http.MethodDelete, "/iaas/api/zones/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		if diags := r.DeleteContext(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("delete returned errors %#v", diags)
+		}
+
+		var deleted []string
+		for _, request := range api.received() {
+			if strings.HasPrefix(request, http.MethodDelete+" ") {
+				deleted = append(deleted, strings.TrimPrefix(request, http.MethodDelete+" "))
+			}
+		}
+		expected := []string{"/iaas/api/cloud-accounts-vsphere/cloud-account-1"}
+		if deleteDefaultZones {
+			expected = append([]string{"/iaas/api/zones/zone-1"}, expected...)
+		}
+		if strings.Join(deleted, ",") != strings.Join(expected, ",") {
+			t.Errorf("delete_default_zones %t: expected the deletion of %v, got %v", deleteDefaultZones, expected, deleted)
+		}
+	}
+}
+
+func TestResourceCloudAccountVsphere_TagsMap(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceCloudAccountVsphere()
+
+	tagsMap := map[string]interface{}{"env": "prod", "keyless": ""}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":     "vsphere",
+		"hostname": "vc.example.com",
+		"username": "administrator@vsphere.local",
+		"password": "secret",
+		"regions":  []interface{}{"Datacenter:datacenter-2"},
+		"tags_map": tagsMap,
+	})
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned errors %#v", diags)
+	}
+
+	expectedTags := []interface{}{
+		map[string]interface{}{"key": "env", "value": "prod"},
+		map[string]interface{}{"key": "keyless", "value": nil},
+	}
+	if sent := api.cloudAccountsVsphere[d.Id()]["tags"]; !reflect.DeepEqual(sent, expectedTags) {
+		t.Errorf("expected the tags %v to be sent, got %v", expectedTags, sent)
+	}
+
+	// The tags are read back in tags_map, the attribute they are managed with
+	if diags := r.ReadContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read returned errors %#v", diags)
+	}
+	if actual := d.Get("tags_map"); !reflect.DeepEqual(actual, tagsMap) {
+		t.Errorf("expected tags_map %v, got %v", tagsMap, actual)
+	}
+	if actual := d.Get("tags").(*schema.Set).Len(); actual != 0 {
+		t.Errorf("expected no tags when tags_map is used, got %d", actual)
+	}
+
+	// Without tags_map in the state, for example after an import, the tags are read in tags
+	imported := r.TestResourceData()
+	imported.SetId(d.Id())
+	if diags := r.ReadContext(context.Background(), imported, c); diags.HasError() {
+		t.Fatalf("read returned errors %#v", diags)
+	}
+	if actual := imported.Get("tags").(*schema.Set).Len(); actual != 2 {
+		t.Errorf("expected 2 tags, got %d", actual)
+	}
+	if actual := imported.Get("tags_map").(map[string]interface{}); len(actual) != 0 {
+		t.Errorf("expected no tags_map when tags is used, got %v", actual)
+	}
+}
+
+func TestResourceCloudAccountVsphere_CreateDefaultZones(t *testing.T) {
+	for _, createDefaultZones := range []bool{false, true} {
+		api := newTestMockAPI(t)
+		c := api.client()
+		r := resourceCloudAccountVsphere()
+
+		var requested map[string]interface{}
+		api.handle(http.MethodPost, "/iaas/api/cloud-accounts-vsphere", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &requested); err != nil {
+				t.Errorf("decoding the create request returned error %s", err)
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			api.createCloudAccountVsphere(w, r)
+		})
+		api.handle(http.MethodGet, "/iaas/api/zones", func(w http.ResponseWriter, r *http.Request) {
+			zones := []interface{}{
+				map[string]interface{}{"id": "zone-1", "name": "datacenter-2", "cloudAccountId": "cloud-account-1"},
+				map[string]interface{}{"id": "zone-2", "name": "other", "cloudAccountId": "cloud-account-other"},
+			}
+			api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": mockAPIPage(zones, r), "totalElements": len(zones)})
+		})
+
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"name":                 "vsphere",
+			"hostname":             "vc.example.com",
+			"username":             "administrator@vsphere.local",
+			"password":             "secret",
+			"regions":              []interface{}{"Datacenter:datacenter-2"},
+			"create_default_zones": createDefaultZones,
+		})
+		if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("create returned errors %#v", diags)
+		}
+
+		if actual, _ := requested["createDefaultZones"].(bool); actual != createDefaultZones {
+			t.Errorf("create_default_zones %t: expected createDefaultZones %t to be requested, got %v", createDefaultZones, createDefaultZones, requested["createDefaultZones"])
+		}
+
+		expected := []interface{}{}
+		if createDefaultZones {
+			expected = []interface{}{"zone-1"}
+		}
+		if actual := d.Get("zone_ids").(*schema.Set).List(); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("create_default_zones %t: expected zone_ids %v, got %v", createDefaultZones, expected, actual)
+		}
+	}
+}
+
+func TestResourceCloudAccountVsphere_WaitForEnumeration(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceCloudAccountVsphere()
+
+	var filters []string
+	api.handle(http.MethodGet, "/iaas/api/fabric-vsphere-datastores", func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("$filter"))
+
+		// The datastores are collected after the second poll
+		content := []interface{}{}
+		if len(filters) > 2 {
+			content = append(content, map[string]interface{}{"id": "datastore-1", "name": "datastore", "cloudAccountIds": []interface{}{"cloud-account-1"}})
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": content, "totalElements": len(content)})
+	})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                 "vsphere",
+		"hostname":             "vc.example.com",
+		"username":             "administrator@vsphere.local",
+		"password":             "secret",
+		"regions":              []interface{}{"Datacenter:datacenter-2"},
+		"wait_for_enumeration": true,
+	})
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned errors %#v", diags)
+	}
+
+	if len(filters) != 3 {
+		t.Errorf("expected the datastores to be polled 3 times, got %d", len(filters))
+	}
+	for _, filter := range filters {
+		if filter != "cloudAccountId eq 'cloud-account-1'" {
+			t.Errorf("expected the datastores of the cloud account to be polled, got filter %q", filter)
+		}
+	}
+}
+
+func TestResourceCloudAccountVsphereCustomizeDiff(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceCloudAccountVsphere()
+
+	enumerations := 0
+	api.handle(http.MethodPost, "/iaas/api/cloud-accounts-vsphere/region-enumeration", func(w http.ResponseWriter, r *http.Request) {
+		enumerations++
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"externalRegionIds": []interface{}{"Datacenter:datacenter-2"}})
+	})
+
+	config := func(validateBeforeCreate bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                   "vsphere",
+			"hostname":               "vc.example.com",
+			"username":               "administrator@vsphere.local",
+			"password":               "secret",
+			"regions":                []interface{}{"Datacenter:datacenter-22"},
+			"validate_before_create": validateBeforeCreate,
+		})
+	}
+
+	// The vCenter is not contacted during plan unless validate_before_create is set
+	if _, err := r.Diff(context.Background(), nil, config(false), c); err != nil {
+		t.Fatalf("plan returned error %s", err)
+	}
+	if enumerations != 0 {
+		t.Errorf("expected no region enumeration without validate_before_create, got %d", enumerations)
+	}
+
+	if _, err := r.Diff(context.Background(), nil, config(true), c); err == nil || !strings.Contains(err.Error(), "Datacenter:datacenter-22") {
+		t.Errorf("expected the undiscoverable region to be reported, got %v", err)
+	}
+	if enumerations != 1 {
+		t.Errorf("expected a single region enumeration with validate_before_create, got %d", enumerations)
+	}
+}
+
+func TestResourceCloudAccountVsphereImport(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceCloudAccountVsphere()
+
+	raw := map[string]interface{}{
+		"name":                    "vsphere",
+		"description":             "test cloud account",
+		"hostname":                "vc.example.com",
+		"username":                "administrator@vsphere.local",
+		"password":                "secret",
+		"regions":                 []interface{}{"Datacenter:datacenter-2"},
+		"accept_self_signed_cert": true,
+	}
+	created := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(context.Background(), created, c); diags.HasError() {
+		t.Fatalf("create returned errors %#v", diags)
+	}
+
+	d := r.Data(&terraform.InstanceState{ID: created.Id()})
+	imported, err := r.Importer.StateContext(context.Background(), d, c)
+	if err != nil {
+		t.Fatalf("import returned error %s", err)
+	}
+	if diags := r.ReadContext(context.Background(), imported[0], c); diags.HasError() {
+		t.Fatalf("read returned errors %#v", diags)
+	}
+	state := imported[0].State()
+	if _, ok := state.Attributes["accept_self_signed_cert"]; ok {
+		t.Errorf("expected accept_self_signed_cert to be left unset on import, got %q", state.Attributes["accept_self_signed_cert"])
+	}
+
+	// The password is not returned by the API, so it is the only argument planned after the import. The attributes
+	// computed by its update are not known until apply.
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), c)
+	if err != nil {
+		t.Fatalf("plan returned error %s", err)
+	}
+	for key, attrDiff := range diff.Attributes {
+		if key != "password" && !attrDiff.NewComputed {
+			t.Errorf("expected no change of %s after import, got %#v", key, diff.Attributes[key])
+		}
+	}
+
+	// A revalidation connects to the vCenter, so it needs the configured value
+	raw["revalidate"] = true
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), c)
+	if err != nil {
+		t.Fatalf("plan returned error %s", err)
+	}
+	if diff == nil || diff.Attributes["accept_self_signed_cert"] == nil || diff.Attributes["accept_self_signed_cert"].New != "true" {
+		t.Errorf("expected accept_self_signed_cert to be planned with a revalidation, got %#v", diff)
+	}
+}
+
+func TestFlattenFailedRegion(t *testing.T) {
+	err := &cloud_account.CreateVSphereCloudAccountBadRequest{Payload: &models.Error{Message: "Unable to connect with password secret"}}
+
+	failedRegion := flattenFailedRegion("Datacenter:datacenter-9", err, "secret")
+	if failedRegion["region"] != "Datacenter:datacenter-9" {
+		t.Errorf("expected the failed region Datacenter:datacenter-9, got %v", failedRegion["region"])
+	}
+	if failedRegion["reason"] != "Unable to connect with password "+redactedSecret {
+		t.Errorf("expected the password to be redacted from the reason, got %v", failedRegion["reason"])
+	}
+}
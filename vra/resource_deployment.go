@@ -11,16 +11,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/blueprint"
 	"github.com/vmware/vra-sdk-go/pkg/client/blueprint_requests"
 	"github.com/vmware/vra-sdk-go/pkg/client/catalog_items"
 	"github.com/vmware/vra-sdk-go/pkg/client/deployment_actions"
 	"github.com/vmware/vra-sdk-go/pkg/client/deployments"
+	"github.com/vmware/vra-sdk-go/pkg/client/project"
 	"github.com/vmware/vra-sdk-go/pkg/models"
+	"gopkg.in/yaml.v2"
 
 	"log"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 )
@@ -40,8 +44,9 @@ func resourceDeployment() *schema.Resource {
 		ReadContext:   resourceDeploymentRead,
 		UpdateContext: resourceDeploymentUpdate,
 		DeleteContext: resourceDeploymentDelete,
+		CustomizeDiff: resourceDeploymentCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceDeploymentImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -56,8 +61,10 @@ func resourceDeployment() *schema.Resource {
 				Computed: true,
 			},
 			"blueprint_content": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"blueprint_id", "catalog_item_id"},
+				ValidateFunc:  validateBlueprintContent,
 			},
 			"catalog_item_id": {
 				Type:     schema.TypeString,
@@ -123,10 +130,27 @@ func resourceDeployment() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"lease_days": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"lease_expire_at"},
+				Description:   "Number of days the lease of the deployment is extended to on create and on every renewal.",
+				ValidateFunc:  validation.IntAtLeast(1),
+			},
 			"lease_expire_at": {
-				Type:     schema.TypeString,
-				Computed: true,
-				Optional: true,
+				Type:             schema.TypeString,
+				Computed:         true,
+				Optional:         true,
+				Description:      "Time at which the lease of the deployment expires, in RFC 3339 format. When set, the lease is changed to expire at this time.",
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEqualLeaseExpireAt,
+			},
+			"lease_renewal_threshold_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "The lease is renewed for lease_days when fewer than this many days are remaining.",
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -146,7 +170,9 @@ func resourceDeployment() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"resources": resourcesSchema(),
+			"resource_addresses": resourceAddressesSchema(),
+			"resources":          resourcesSchema(),
+			"resources_by_type":  resourcesByTypeSchema(),
 			// TODO: Add plan / simulate feature
 			"status": {
 				Type:     schema.TypeString,
@@ -310,7 +336,7 @@ func resourceDeploymentCreate(ctx context.Context, d *schema.ResourceData, m int
 	stateChangeFunc := resource.StateChangeConf{
 		Delay:      5 * time.Second,
 		Pending:    []string{models.DeploymentStatusCREATEINPROGRESS, models.DeploymentStatusUPDATEINPROGRESS},
-		Refresh:    deploymentStatusRefreshFunc(*apiClient, d.Id()),
+		Refresh:    deploymentStatusRefreshFunc(*apiClient, d.Id(), m.(*Client).apiTimeout),
 		Target:     []string{models.DeploymentStatusCREATESUCCESSFUL, models.DeploymentStatusUPDATESUCCESSFUL},
 		Timeout:    d.Timeout(schema.TimeoutCreate),
 		MinTimeout: 5 * time.Second,
@@ -326,9 +352,43 @@ func resourceDeploymentCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	d.SetId(deploymentID.(string))
+
+	// The deployment is kept, rather than tainted, when its lease or owner cannot be changed. The read sets the actual
+	// lease and owner, so that the next plan changes them again with an update.
+	var diags diag.Diagnostics
+	if v, ok := d.GetOk("lease_days"); ok {
+		leaseExpireAt := time.Now().UTC().AddDate(0, 0, v.(int)).Format(time.RFC3339)
+		if err := runChangeLeaseDeploymentAction(ctx, d, m, apiClient, strfmt.UUID(d.Id()), leaseExpireAt); err != nil {
+			// lease_days is not read back, so it is cleared for the next plan to show it again
+			d.Set("lease_days", nil)
+			diags = append(diags, createdDeploymentWarning(d, "lease", err))
+		}
+	} else if v, ok := d.GetOk("lease_expire_at"); ok {
+		if err := runChangeLeaseDeploymentAction(ctx, d, m, apiClient, strfmt.UUID(d.Id()), v.(string)); err != nil {
+			diags = append(diags, createdDeploymentWarning(d, "lease", err))
+		}
+	}
+
+	if v, ok := d.GetOk("owner"); ok {
+		if err := changeCreatedDeploymentOwner(ctx, d, m, apiClient, strfmt.UUID(d.Id()), v.(string)); err != nil {
+			diags = append(diags, createdDeploymentWarning(d, "owner", err))
+		}
+	}
+
 	log.Printf("Finished to create vra_deployment resource with name %s", d.Get("name"))
 
-	return resourceDeploymentRead(ctx, d, m)
+	return append(diags, resourceDeploymentRead(ctx, d, m)...)
+}
+
+// createdDeploymentWarning reports that the given property of a created deployment could not be changed, and will be
+// changed on the next apply
+func createdDeploymentWarning(d *schema.ResourceData, property string, err error) diag.Diagnostic {
+	log.Printf("[WARN] Unable to change the %s of deployment %s: %s", property, d.Id(), err)
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Unable to change the %s of deployment %s", property, d.Id()),
+		Detail:   fmt.Sprintf("The deployment was created, but changing its %s failed: %s. The %s is changed on the next apply.", property, err, property),
+	}
 }
 
 func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -348,7 +408,7 @@ func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, m inter
 			WithExpandLastRequest(withBool(true)).
 			WithExpandProject(withBool(expandProject)).
 			WithAPIVersion(withString(DeploymentsAPIVersion)).
-			WithTimeout(IncreasedTimeOut))
+			WithTimeout(m.(*Client).apiTimeout))
 	if err != nil {
 		switch err.(type) {
 		case *deployments.GetDeploymentByIDUsingGETNotFound:
@@ -389,7 +449,11 @@ func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	d.Set("last_updated_at", deployment.LastUpdatedAt)
 	d.Set("last_updated_by", deployment.LastUpdatedBy)
-	d.Set("lease_expire_at", deployment.LeaseExpireAt)
+	if leaseExpireAt := time.Time(deployment.LeaseExpireAt); !leaseExpireAt.IsZero() {
+		d.Set("lease_expire_at", deployment.LeaseExpireAt.String())
+	} else {
+		d.Set("lease_expire_at", "")
+	}
 	d.Set("name", deployment.Name)
 	d.Set("org_id", deployment.OrgID)
 	d.Set("owner", deployment.OwnedBy)
@@ -400,10 +464,18 @@ func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	d.Set("project_id", deployment.ProjectID)
 
+	if err := d.Set("resource_addresses", flattenResourceAddresses(deployment.Resources)); err != nil {
+		return diag.Errorf("error setting resource_addresses in deployment - error: %#v", err)
+	}
+
 	if err := d.Set("resources", flattenResources(deployment.Resources)); err != nil {
 		return diag.Errorf("error setting resources in deployment - error: %#v", err)
 	}
 
+	if err := d.Set("resources_by_type", flattenResourcesByType(deployment.Resources)); err != nil {
+		return diag.Errorf("error setting resources_by_type in deployment - error: %#v", err)
+	}
+
 	d.Set("status", deployment.Status)
 
 	log.Printf("Finished reading the vra_deployment resource with name '%s'. Current status: '%s'", d.Get("name"), d.Get("status"))
@@ -430,7 +502,7 @@ func resourceDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m int
 		}
 
 		if d.HasChange("inputs") {
-			err := runDeploymentUpdateAction(ctx, d, apiClient, deploymentUUID)
+			err := runDeploymentUpdateAction(ctx, d, m, apiClient, deploymentUUID)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -439,16 +511,16 @@ func resourceDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m int
 		stateChangeFunc := resource.StateChangeConf{
 			Delay:      5 * time.Second,
 			Pending:    []string{models.DeploymentStatusCREATEINPROGRESS, models.DeploymentStatusUPDATEINPROGRESS},
-			Refresh:    deploymentStatusRefreshFunc(*apiClient, d.Id()),
+			Refresh:    deploymentStatusRefreshFunc(*apiClient, d.Id(), m.(*Client).apiTimeout),
 			Target:     []string{models.DeploymentStatusCREATESUCCESSFUL, models.DeploymentStatusUPDATESUCCESSFUL},
-			Timeout:    d.Timeout(schema.TimeoutCreate),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
 			MinTimeout: 5 * time.Second,
 		}
 
 		if _, err := stateChangeFunc.WaitForStateContext(ctx); err != nil {
 			readErrors := resourceDeploymentRead(ctx, d, m)
 			if readErrors.HasError() {
-				return append(readErrors, diag.Errorf("failed to create deployment: %v", err.Error())...)
+				return append(readErrors, diag.Errorf("failed to update deployment: %v", err.Error())...)
 			}
 			return diag.FromErr(err)
 		}
@@ -456,7 +528,25 @@ func resourceDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	if d.HasChange("owner") {
 		deploymentUUID := strfmt.UUID(d.Id())
-		err := runChangeOwnerDeploymentAction(ctx, d, apiClient, deploymentUUID)
+		err := runChangeOwnerDeploymentAction(ctx, d, m, apiClient, deploymentUUID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if v, ok := d.GetOk("lease_days"); ok {
+		oldLeaseExpireAt, _ := d.GetChange("lease_expire_at")
+		if d.HasChange("lease_days") || deploymentLeaseNeedsRenewal(oldLeaseExpireAt.(string), d.Get("lease_renewal_threshold_days").(int), time.Now()) {
+			deploymentUUID := strfmt.UUID(d.Id())
+			leaseExpireAt := time.Now().UTC().AddDate(0, 0, v.(int)).Format(time.RFC3339)
+			err := runChangeLeaseDeploymentAction(ctx, d, m, apiClient, deploymentUUID, leaseExpireAt)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else if d.HasChange("lease_expire_at") && d.Get("lease_expire_at").(string) != "" {
+		deploymentUUID := strfmt.UUID(d.Id())
+		err := runChangeLeaseDeploymentAction(ctx, d, m, apiClient, deploymentUUID, d.Get("lease_expire_at").(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -481,7 +571,7 @@ func resourceDeploymentDelete(ctx context.Context, d *schema.ResourceData, m int
 	stateChangeFunc := resource.StateChangeConf{
 		Delay:      5 * time.Second,
 		Pending:    []string{reflect.TypeOf((*deployments.GetDeploymentByIDUsingGETOK)(nil)).String()},
-		Refresh:    deploymentDeleteStatusRefreshFunc(*apiClient, d.Id()),
+		Refresh:    deploymentDeleteStatusRefreshFunc(*apiClient, d.Id(), m.(*Client).apiTimeout),
 		Target:     []string{reflect.TypeOf((*deployments.GetDeploymentByIDUsingGETNotFound)(nil)).String()},
 		Timeout:    d.Timeout(schema.TimeoutDelete),
 		MinTimeout: 5 * time.Second,
@@ -496,6 +586,140 @@ func resourceDeploymentDelete(ctx context.Context, d *schema.ResourceData, m int
 	return nil
 }
 
+// resourceDeploymentImport accepts either the deployment id or "<project>/<name>", where project is the id or the name
+// of the project. The inputs of the deployment are imported as well, since Read only refreshes configured inputs.
+func resourceDeploymentImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	apiClient := m.(*Client).apiClient
+
+	id := d.Id()
+	if projectRef, name, ok := parseDeploymentImportID(id); ok {
+		projectID, err := resolveProjectID(apiClient, projectRef, m.(*Client).apiTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := apiClient.Deployments.GetDeploymentsUsingGET(
+			deployments.NewGetDeploymentsUsingGETParams().
+				WithName(withString(name)).
+				WithProjects([]string{projectID}).
+				WithAPIVersion(withString(DeploymentsAPIVersion)).
+				WithTimeout(m.(*Client).apiTimeout))
+		if err != nil {
+			return nil, err
+		}
+
+		id, err = deploymentIDByName(resp.Payload.Content, projectRef, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := apiClient.Deployments.GetDeploymentByIDUsingGET(
+		deployments.NewGetDeploymentByIDUsingGETParams().
+			WithDeploymentID(strfmt.UUID(id)).
+			WithAPIVersion(withString(DeploymentsAPIVersion)).
+			WithTimeout(m.(*Client).apiTimeout))
+	if err != nil {
+		switch err.(type) {
+		case *deployments.GetDeploymentByIDUsingGETNotFound:
+			return nil, fmt.Errorf("deployment %s not found", id)
+		}
+		return nil, err
+	}
+
+	deployment := resp.Payload
+	d.SetId(deployment.ID.String())
+	d.Set("blueprint_id", deployment.BlueprintID)
+	d.Set("blueprint_version", deployment.BlueprintVersion)
+	d.Set("catalog_item_id", deployment.CatalogItemID)
+	d.Set("catalog_item_version", deployment.CatalogItemVersion)
+
+	// Only the inputs that differ from the defaults of the catalog item or blueprint are imported, like the ones a
+	// configuration would set. All the inputs are imported if the schema cannot be read.
+	inputs, _ := deployment.Inputs.(map[string]interface{})
+	inputsSchema, err := getDeploymentInputsSchema(d, apiClient)
+	if err != nil {
+		log.Printf("[WARN] Importing all the inputs of deployment %s, unable to read the schema of its inputs: %s", id, err)
+	} else if inputsSchema != nil {
+		inputs = deploymentInputsWithoutDefaults(inputs, inputsSchema)
+	}
+	if err := d.Set("inputs", expandInputsToString(inputs)); err != nil {
+		return nil, fmt.Errorf("error setting deployment inputs - error: %#v", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// deploymentInputsWithoutDefaults returns the inputs whose value differs from the default of the input in the schema
+func deploymentInputsWithoutDefaults(inputs map[string]interface{}, inputsSchema *models.PropertyDefinition) map[string]interface{} {
+	withoutDefaults := make(map[string]interface{}, len(inputs))
+	for name, value := range inputs {
+		if definition, ok := inputsSchema.Properties[name]; ok && definition.Default != nil && fmt.Sprint(definition.Default) == fmt.Sprint(value) {
+			continue
+		}
+		withoutDefaults[name] = value
+	}
+	return withoutDefaults
+}
+
+// parseDeploymentImportID splits an import id of the form "<project>/<name>". It returns false for a deployment id.
+func parseDeploymentImportID(id string) (string, string, bool) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	if parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// resolveProjectID returns the id of the project with the given id or name
+func resolveProjectID(apiClient *client.MulticloudIaaS, projectRef string, timeout time.Duration) (string, error) {
+	if strfmt.IsUUID(projectRef) {
+		return projectRef, nil
+	}
+
+	// Quotes are escaped by doubling them in OData string literals
+	filter := fmt.Sprintf("name eq '%s'", strings.ReplaceAll(projectRef, "'", "''"))
+	resp, err := apiClient.Project.GetProjects(project.NewGetProjectsParams().WithDollarFilter(withString(filter)).WithTimeout(timeout))
+	if err != nil {
+		return "", err
+	}
+
+	// The number of matches is taken from the total, since the matching projects may span several pages
+	projects := resp.GetPayload().Content
+	if len(projects) == 0 {
+		return "", fmt.Errorf("project %s not found", projectRef)
+	}
+	if len(projects) > 1 || resp.GetPayload().TotalElements > 1 {
+		return "", fmt.Errorf("more than one project is named %s, use the project id instead", projectRef)
+	}
+
+	return *projects[0].ID, nil
+}
+
+// deploymentIDByName returns the id of the only deployment named exactly name. The deployments API matches names
+// loosely, so the results are filtered again here.
+func deploymentIDByName(deploymentList []*models.Deployment, projectRef, name string) (string, error) {
+	var ids []string
+	for _, deployment := range deploymentList {
+		if deployment.Name != nil && *deployment.Name == name {
+			ids = append(ids, deployment.ID.String())
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no deployment named %s found in project %s", name, projectRef)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d deployments named %s found in project %s, import one of them by id: %s", len(ids), name, projectRef, strings.Join(ids, ", "))
+	}
+}
+
 // Gets the inputs and their types as map[string]string
 func getInputTypesMap(d *schema.ResourceData, apiClient *client.MulticloudIaaS) map[string]string {
 	inputTypesMap := make(map[string]string)
@@ -597,59 +821,155 @@ func getBlueprintInputTypesMap(apiClient *client.MulticloudIaaS, blueprintID str
 }
 
 func getCatalogItemSchema(apiClient *client.MulticloudIaaS, catalogItemID string, catalogItemVersion string) (map[string]interface{}, error) {
+	catalogItemSchema, err := getCatalogItemRawSchema(apiClient, catalogItemID, catalogItemVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if catalogItemSchema != nil && (catalogItemSchema.(map[string]interface{}))["properties"] != nil {
+		inputsSchemaMap := (catalogItemSchema.(map[string]interface{}))["properties"].(map[string]interface{})
+		return inputsSchemaMap, nil
+	}
+	return make(map[string]interface{}), nil
+}
+
+// getCatalogItemRawSchema returns the JSON schema of the inputs of the catalog item version, or of the catalog item
+// when no version is given
+func getCatalogItemRawSchema(apiClient *client.MulticloudIaaS, catalogItemID string, catalogItemVersion string) (interface{}, error) {
 	// Getting the catalog item schema
 	log.Printf("Getting the schema for catalog item: %v version: %v", catalogItemID, catalogItemVersion)
-	var catalogItemSchema interface{}
 	if catalogItemVersion == "" {
 		getItemResp, err := apiClient.CatalogItems.GetCatalogItemUsingGET1(catalog_items.NewGetCatalogItemUsingGET1Params().WithID(strfmt.UUID(catalogItemID)))
 		if err != nil {
 			return nil, err
 		}
-		catalogItemSchema = getItemResp.GetPayload().Schema
-	} else {
-		getVersionResp, err := apiClient.CatalogItems.GetVersionByIDUsingGET(catalog_items.NewGetVersionByIDUsingGETParams().WithID(strfmt.UUID(catalogItemID)).WithVersionID(catalogItemVersion))
-		if err != nil {
-			return nil, err
-		}
-		catalogItemSchema = getVersionResp.GetPayload().Schema
+		return getItemResp.GetPayload().Schema, nil
 	}
 
-	if catalogItemSchema != nil && (catalogItemSchema.(map[string]interface{}))["properties"] != nil {
-		inputsSchemaMap := (catalogItemSchema.(map[string]interface{}))["properties"].(map[string]interface{})
-		return inputsSchemaMap, nil
+	getVersionResp, err := apiClient.CatalogItems.GetVersionByIDUsingGET(catalog_items.NewGetVersionByIDUsingGETParams().WithID(strfmt.UUID(catalogItemID)).WithVersionID(catalogItemVersion))
+	if err != nil {
+		return nil, err
 	}
-	return make(map[string]interface{}), nil
+	return getVersionResp.GetPayload().Schema, nil
 }
 
 func getBlueprintSchema(apiClient *client.MulticloudIaaS, blueprintID string, blueprintVersion string) (map[string]models.PropertyDefinition, error) {
+	blueprintInputsSchema, err := getBlueprintInputsSchema(apiClient, blueprintID, blueprintVersion)
+	if err != nil {
+		return nil, err
+	}
+	return blueprintInputsSchema.Properties, nil
+}
+
+// getBlueprintInputsSchema returns the schema of the inputs of the blueprint version, or of the blueprint when no
+// version is given
+func getBlueprintInputsSchema(apiClient *client.MulticloudIaaS, blueprintID string, blueprintVersion string) (*models.PropertyDefinition, error) {
 	// Getting the blueprint inputs schema
 	log.Printf("Getting the schema for catalog item: %v version: %v", blueprintID, blueprintVersion)
-	var blueprintInputsSchema map[string]models.PropertyDefinition
 	if blueprintVersion == "" {
 		getItemResp, err := apiClient.Blueprint.GetBlueprintInputsSchemaUsingGET1(blueprint.NewGetBlueprintInputsSchemaUsingGET1Params().WithBlueprintID(blueprintID))
 		if err != nil {
 			return nil, err
 		}
-		blueprintInputsSchema = getItemResp.GetPayload().Properties
-	} else {
-		getVersionResp, err := apiClient.Blueprint.GetBlueprintVersionInputsSchemaUsingGET1(
-			blueprint.NewGetBlueprintVersionInputsSchemaUsingGET1Params().WithBlueprintID(blueprintID).
-				WithVersion(blueprintVersion))
+		return getItemResp.GetPayload(), nil
+	}
+
+	getVersionResp, err := apiClient.Blueprint.GetBlueprintVersionInputsSchemaUsingGET1(
+		blueprint.NewGetBlueprintVersionInputsSchemaUsingGET1Params().WithBlueprintID(blueprintID).
+			WithVersion(blueprintVersion))
+	if err != nil {
+		return nil, err
+	}
+	return getVersionResp.GetPayload(), nil
+}
+
+// getDeploymentInputsSchema returns the schema of the inputs of the catalog item or blueprint of the deployment, or
+// nil if the deployment has neither
+func getDeploymentInputsSchema(d resourceGetter, apiClient *client.MulticloudIaaS) (*models.PropertyDefinition, error) {
+	if catalogItemID := d.Get("catalog_item_id").(string); catalogItemID != "" {
+		rawSchema, err := getCatalogItemRawSchema(apiClient, catalogItemID, d.Get("catalog_item_version").(string))
+		if err != nil {
+			return nil, err
+		}
+
+		// The catalog item schema is a JSON schema, like the blueprint inputs schema
+		b, err := json.Marshal(rawSchema)
 		if err != nil {
 			return nil, err
 		}
-		blueprintInputsSchema = getVersionResp.GetPayload().Properties
+		inputsSchema := &models.PropertyDefinition{}
+		if err := json.Unmarshal(b, inputsSchema); err != nil {
+			return nil, err
+		}
+		return inputsSchema, nil
+	}
+
+	if blueprintID := d.Get("blueprint_id").(string); blueprintID != "" {
+		return getBlueprintInputsSchema(apiClient, blueprintID, d.Get("blueprint_version").(string))
+	}
+
+	return nil, nil
+}
+
+// validateDeploymentInputs returns an error listing every required input that is missing, and every input that
+// cannot be converted to its type or is not one of its allowed values. Inputs that are not in the schema are not
+// validated.
+func validateDeploymentInputs(inputs map[string]interface{}, inputsSchema *models.PropertyDefinition) error {
+	var problems []string
+
+	required := append([]string{}, inputsSchema.Required...)
+	sort.Strings(required)
+	for _, name := range required {
+		if _, ok := inputs[name]; !ok && inputsSchema.Properties[name].Default == nil {
+			problems = append(problems, fmt.Sprintf("inputs.%s: the input is required", name))
+		}
+	}
+
+	for _, name := range sortedKeys(inputs) {
+		definition, ok := inputsSchema.Properties[name]
+		if !ok {
+			continue
+		}
+
+		value := inputs[name]
+		inputsByType, err := getInputsByType(map[string]interface{}{name: value}, map[string]string{name: definition.Type})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("inputs.%s: %q is not a valid %s", name, value, definition.Type))
+			continue
+		}
+
+		if len(definition.Enum) != 0 && !inputEnumContains(definition.Enum, inputsByType[name]) {
+			allowed := make([]string, 0, len(definition.Enum))
+			for _, v := range definition.Enum {
+				allowed = append(allowed, fmt.Sprint(v))
+			}
+			problems = append(problems, fmt.Sprintf("inputs.%s: %q is not one of the allowed values %s", name, value, strings.Join(allowed, ", ")))
+		}
 	}
-	return blueprintInputsSchema, nil
+
+	if len(problems) != 0 {
+		return fmt.Errorf("invalid deployment inputs:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
 }
 
-func deploymentStatusRefreshFunc(apiClient client.MulticloudIaaS, id string) resource.StateRefreshFunc {
+func inputEnumContains(enum []interface{}, value interface{}) bool {
+	for _, v := range enum {
+		if fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func deploymentStatusRefreshFunc(apiClient client.MulticloudIaaS, id string, timeout time.Duration) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		ret, err := apiClient.Deployments.GetDeploymentByIDUsingGET(
 			deployments.NewGetDeploymentByIDUsingGETParams().
 				WithDeploymentID(strfmt.UUID(id)).
 				WithExpandLastRequest(withBool(true)).
-				WithAPIVersion(withString(DeploymentsAPIVersion)))
+				WithAPIVersion(withString(DeploymentsAPIVersion)).
+				WithTimeout(timeout))
 		if err != nil {
 			return id, models.DeploymentStatusCREATEFAILED, err
 		}
@@ -757,9 +1077,9 @@ func updateDeploymentWithNewBlueprint(ctx context.Context, d *schema.ResourceDat
 	stateChangeFunc := resource.StateChangeConf{
 		Delay:      5 * time.Second,
 		Pending:    []string{models.DeploymentStatusCREATEINPROGRESS, models.DeploymentStatusUPDATEINPROGRESS},
-		Refresh:    deploymentStatusRefreshFunc(*apiClient, deploymentID),
+		Refresh:    deploymentStatusRefreshFunc(*apiClient, deploymentID, m.(*Client).apiTimeout),
 		Target:     []string{models.DeploymentStatusCREATESUCCESSFUL, models.DeploymentStatusUPDATESUCCESSFUL},
-		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
 		MinTimeout: 5 * time.Second,
 	}
 
@@ -796,7 +1116,7 @@ func updateDeploymentMetadata(d *schema.ResourceData, apiClient *client.Multiclo
 	return nil
 }
 
-func runDeploymentUpdateAction(ctx context.Context, d *schema.ResourceData, apiClient *client.MulticloudIaaS, deploymentUUID strfmt.UUID) error {
+func runDeploymentUpdateAction(ctx context.Context, d *schema.ResourceData, m interface{}, apiClient *client.MulticloudIaaS, deploymentUUID strfmt.UUID) error {
 	log.Printf("Noticed changes to inputs. Starting to update deployment with inputs")
 	// Get the deployment actions
 	deploymentActions, err := apiClient.DeploymentActions.GetDeploymentActionsUsingGET(deployment_actions.
@@ -868,7 +1188,7 @@ func runDeploymentUpdateAction(ctx context.Context, d *schema.ResourceData, apiC
 	}
 
 	reason := "Updated deployment inputs from vRA provider for Terraform."
-	err = runAction(ctx, d, apiClient, deploymentUUID, actionID, inputs, reason)
+	err = runAction(ctx, d, m, apiClient, deploymentUUID, actionID, inputs, reason)
 	if err != nil {
 		return err
 	}
@@ -930,6 +1250,16 @@ func getInputTypesMapFromSchema(schema map[string]interface{}) (map[string]strin
 	return inputTypesMap, nil
 }
 
+// validateBlueprintContent checks that the blueprint content parses as YAML, so that invalid content fails the plan
+// rather than the blueprint request
+func validateBlueprintContent(v interface{}, k string) (ws []string, errors []error) {
+	var content interface{}
+	if err := yaml.Unmarshal([]byte(v.(string)), &content); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not valid YAML: %s", k, err))
+	}
+	return
+}
+
 // Returns a map of string, string with input variables and their types defined in the vRA blueprint
 func getInputTypesMapFromBlueprintInputsSchema(schema map[string]models.PropertyDefinition) (map[string]string, error) {
 	log.Printf("Getting the map of inputs and their types")
@@ -1003,7 +1333,7 @@ func getDeploymentActionInputTypesMap(apiClient *client.MulticloudIaaS, deployme
 	return inputTypesMap, nil
 }
 
-func runChangeOwnerDeploymentAction(ctx context.Context, d *schema.ResourceData, apiClient *client.MulticloudIaaS, deploymentUUID strfmt.UUID) error {
+func runChangeOwnerDeploymentAction(ctx context.Context, d *schema.ResourceData, m interface{}, apiClient *client.MulticloudIaaS, deploymentUUID strfmt.UUID) error {
 	oldOwner, newOwner := d.GetChange("owner")
 	log.Printf("Noticed changes to owner. Starting to change deployment owner from %s to %s", oldOwner.(string), newOwner.(string))
 
@@ -1032,7 +1362,7 @@ func runChangeOwnerDeploymentAction(ctx context.Context, d *schema.ResourceData,
 	}
 
 	reason := "Updated deployment owner from vRA provider for Terraform."
-	err = runAction(ctx, d, apiClient, deploymentUUID, actionID, inputs, reason)
+	err = runAction(ctx, d, m, apiClient, deploymentUUID, actionID, inputs, reason)
 	if err != nil {
 		return err
 	}
@@ -1041,7 +1371,148 @@ func runChangeOwnerDeploymentAction(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func runAction(ctx context.Context, d *schema.ResourceData, apiClient *client.MulticloudIaaS, deploymentUUID strfmt.UUID, actionID string, inputs map[string]interface{}, reason string) error {
+// changeCreatedDeploymentOwner hands a newly created deployment over to the configured owner, unless the user that
+// requested it is already the owner
+func changeCreatedDeploymentOwner(ctx context.Context, d *schema.ResourceData, m interface{}, apiClient *client.MulticloudIaaS, deploymentUUID strfmt.UUID, owner string) error {
+	resp, err := apiClient.Deployments.GetDeploymentByIDUsingGET(
+		deployments.NewGetDeploymentByIDUsingGETParams().
+			WithDeploymentID(deploymentUUID).
+			WithAPIVersion(withString(DeploymentsAPIVersion)).
+			WithTimeout(m.(*Client).apiTimeout))
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(resp.Payload.OwnedBy, owner) {
+		return nil
+	}
+
+	return runChangeOwnerDeploymentAction(ctx, d, m, apiClient, deploymentUUID)
+}
+
+func runChangeLeaseDeploymentAction(ctx context.Context, d *schema.ResourceData, m interface{}, apiClient *client.MulticloudIaaS, deploymentUUID strfmt.UUID, leaseExpireAt string) error {
+	log.Printf("Starting to change lease of deployment %s to expire at %s", deploymentUUID, leaseExpireAt)
+
+	// Get the deployment actionID for Change Lease
+	isActionValid, actionID, err := getDeploymentDay2ActionID(apiClient, deploymentUUID, ChangeLeaseDeploymentActionName)
+	if err != nil {
+		return fmt.Errorf("unable to renew the deployment lease. %s", err.Error())
+	}
+
+	if !isActionValid {
+		return fmt.Errorf("unable to renew the deployment lease, 'Change Lease' action is not found or supported")
+	}
+
+	actionInputs := make(map[string]interface{})
+	actionInputs["Lease Expiration Date"] = leaseExpireAt
+
+	actionInputTypesMap, err := getDeploymentActionInputTypesMap(apiClient, deploymentUUID, actionID)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := getInputsByType(actionInputs, actionInputTypesMap)
+	if err != nil {
+		return fmt.Errorf("unable to create action inputs for %v. %v", actionID, err.Error())
+	}
+
+	reason := "Renewed deployment lease from vRA provider for Terraform."
+	err = runAction(ctx, d, m, apiClient, deploymentUUID, actionID, inputs, reason)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Finished changing lease for vra_deployment %s to expire at %s", d.Get("name").(string), leaseExpireAt)
+	return nil
+}
+
+// suppressEqualLeaseExpireAt suppresses the diff between two representations of the same lease expiration time, since
+// the API returns it with milliseconds
+func suppressEqualLeaseExpireAt(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+// deploymentLeaseNeedsRenewal returns true if the lease expires within thresholdDays of now. A deployment without
+// a lease expiration never needs a renewal.
+func deploymentLeaseNeedsRenewal(leaseExpireAt string, thresholdDays int, now time.Time) bool {
+	if leaseExpireAt == "" {
+		return false
+	}
+
+	expireAt, err := time.Parse(time.RFC3339, leaseExpireAt)
+	if err != nil {
+		log.Printf("[WARN] Unable to parse deployment lease expiration %q: %s", leaseExpireAt, err)
+		return false
+	}
+
+	return expireAt.Sub(now) < time.Duration(thresholdDays)*24*time.Hour
+}
+
+// resourceDeploymentCustomizeDiff plans a lease renewal when the lease of the deployment is about to expire
+func resourceDeploymentCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := validateDeploymentInputsDiff(d, m.(*Client).apiClient); err != nil {
+		return err
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	if _, ok := d.GetOk("lease_days"); !ok {
+		return nil
+	}
+
+	if deploymentLeaseNeedsRenewal(d.Get("lease_expire_at").(string), d.Get("lease_renewal_threshold_days").(int), time.Now()) {
+		log.Printf("[DEBUG] Lease of deployment %s expires at %s, planning a renewal", d.Id(), d.Get("lease_expire_at"))
+		return d.SetNewComputed("lease_expire_at")
+	}
+
+	return nil
+}
+
+// validateDeploymentInputsDiff validates the inputs of a new deployment, or the changed inputs of a deployment, against
+// the inputs schema of its catalog item or blueprint. The validation is skipped when the inputs or their catalog item
+// or blueprint are not known until apply, or when the schema cannot be fetched.
+func validateDeploymentInputsDiff(d *schema.ResourceDiff, apiClient *client.MulticloudIaaS) error {
+	keys := []string{"inputs", "catalog_item_id", "catalog_item_version", "blueprint_id", "blueprint_version", "blueprint_content"}
+
+	changed := d.Id() == ""
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+		changed = changed || d.HasChange(key)
+	}
+	if !changed {
+		return nil
+	}
+
+	// The inputs schema of inline blueprint content is not available before the deployment is requested
+	if d.Get("blueprint_content").(string) != "" {
+		return nil
+	}
+
+	inputsSchema, err := getDeploymentInputsSchema(d, apiClient)
+	if err != nil {
+		log.Printf("[WARN] Skipping validation of the deployment inputs, unable to get the inputs schema: %s", err)
+		return nil
+	}
+	if inputsSchema == nil {
+		return nil
+	}
+
+	return validateDeploymentInputs(d.Get("inputs").(map[string]interface{}), inputsSchema)
+}
+
+func runAction(ctx context.Context, d *schema.ResourceData, m interface{}, apiClient *client.MulticloudIaaS, deploymentUUID strfmt.UUID, actionID string, inputs map[string]interface{}, reason string) error {
 	resourceActionRequest := models.ResourceActionRequest{
 		ActionID: actionID,
 		Reason:   reason,
@@ -1062,7 +1533,7 @@ func runAction(ctx context.Context, d *schema.ResourceData, apiClient *client.Mu
 	stateChangeFunc := resource.StateChangeConf{
 		Delay:      5 * time.Second,
 		Pending:    []string{models.RequestStatusPENDING, models.RequestStatusINITIALIZATION, models.RequestStatusCHECKINGAPPROVAL, models.RequestStatusAPPROVALPENDING, models.RequestStatusINPROGRESS},
-		Refresh:    deploymentActionStatusRefreshFunc(*apiClient, deploymentUUID, requestID),
+		Refresh:    deploymentActionStatusRefreshFunc(*apiClient, deploymentUUID, requestID, m.(*Client).apiTimeout),
 		Target:     []string{models.RequestStatusCOMPLETION, models.RequestStatusAPPROVALREJECTED, models.RequestStatusABORTED, models.RequestStatusSUCCESSFUL, models.RequestStatusFAILED},
 		Timeout:    d.Timeout(schema.TimeoutUpdate),
 		MinTimeout: 5 * time.Second,
@@ -1073,14 +1544,14 @@ func runAction(ctx context.Context, d *schema.ResourceData, apiClient *client.Mu
 	return nil
 }
 
-func deploymentActionStatusRefreshFunc(apiClient client.MulticloudIaaS, deploymentUUID strfmt.UUID, requestID strfmt.UUID) resource.StateRefreshFunc {
+func deploymentActionStatusRefreshFunc(apiClient client.MulticloudIaaS, deploymentUUID strfmt.UUID, requestID strfmt.UUID, timeout time.Duration) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		ret, err := apiClient.Deployments.GetDeploymentByIDUsingGET(
 			deployments.NewGetDeploymentByIDUsingGETParams().
 				WithDeploymentID(deploymentUUID).
 				WithExpandLastRequest(withBool(true)).
 				WithAPIVersion(withString(DeploymentsAPIVersion)).
-				WithTimeout(IncreasedTimeOut))
+				WithTimeout(timeout))
 		if err != nil {
 			return "", models.RequestStatusFAILED, err
 		}
@@ -1102,13 +1573,14 @@ func deploymentActionStatusRefreshFunc(apiClient client.MulticloudIaaS, deployme
 	}
 }
 
-func deploymentDeleteStatusRefreshFunc(apiClient client.MulticloudIaaS, id string) resource.StateRefreshFunc {
+func deploymentDeleteStatusRefreshFunc(apiClient client.MulticloudIaaS, id string, timeout time.Duration) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		ret, err := apiClient.Deployments.GetDeploymentByIDUsingGET(
 			deployments.NewGetDeploymentByIDUsingGETParams().
 				WithDeploymentID(strfmt.UUID(id)).
 				WithExpandLastRequest(withBool(true)).
-				WithAPIVersion(withString(DeploymentsAPIVersion)))
+				WithAPIVersion(withString(DeploymentsAPIVersion)).
+				WithTimeout(timeout))
 		if err != nil {
 			switch err.(type) {
 			case *deployments.GetDeploymentByIDUsingGETNotFound:
@@ -0,0 +1,290 @@
+package vra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/deployment_actions"
+	"github.com/vmware/vra-sdk-go/pkg/client/requests"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceDeploymentAction runs a day-2 action on a deployment, or on a resource of a deployment, when it is created.
+// Every argument forces a new resource, so changing the inputs or the triggers runs the action again.
+func resourceDeploymentAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeploymentActionCreate,
+		ReadContext:   resourceDeploymentActionRead,
+		DeleteContext: resourceDeploymentActionDelete,
+
+		Schema: map[string]*schema.Schema{
+			"action_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the day-2 action to run, e.g. Deployment.PowerOff or Cloud.vSphere.Machine.Reboot.",
+			},
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the deployment to run the action on.",
+			},
+			"inputs": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Inputs of the action. Values are converted to the types defined in the schema of the action.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "Day-2 action requested from vRA provider for Terraform.",
+				Description: "The reason recorded with the action request.",
+			},
+			"resource_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The id of the resource of the deployment to run the action on. If not set, the action runs on the deployment.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values which, when changed, run the action again.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"completed_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"details": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"outputs": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"requested_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceDeploymentActionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("Starting to create vra_deployment_action resource")
+	apiClient := m.(*Client).apiClient
+	apiTimeout := m.(*Client).apiTimeout
+
+	actionID := d.Get("action_id").(string)
+	deploymentUUID := strfmt.UUID(d.Get("deployment_id").(string))
+	resourceUUID := strfmt.UUID(d.Get("resource_id").(string))
+
+	inputs, err := getDeploymentActionInputs(apiClient, deploymentUUID, resourceUUID, actionID, d.Get("inputs").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceActionRequest := models.ResourceActionRequest{
+		ActionID: actionID,
+		Inputs:   inputs,
+		Reason:   d.Get("reason").(string),
+	}
+
+	var actionRequest *models.Request
+	if resourceUUID != "" {
+		resp, err := apiClient.DeploymentActions.SubmitResourceActionRequestUsingPOST(
+			deployment_actions.NewSubmitResourceActionRequestUsingPOSTParams().
+				WithAPIVersion(withString(DeploymentsAPIVersion)).
+				WithDeploymentID(deploymentUUID).
+				WithResourceID(resourceUUID).
+				WithActionRequest(&resourceActionRequest).
+				WithTimeout(apiTimeout))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		actionRequest = resp.GetPayload()
+	} else {
+		resp, err := apiClient.DeploymentActions.SubmitDeploymentActionRequestUsingPOST(
+			deployment_actions.NewSubmitDeploymentActionRequestUsingPOSTParams().
+				WithAPIVersion(withString(DeploymentsAPIVersion)).
+				WithDeploymentID(deploymentUUID).
+				WithActionRequest(&resourceActionRequest).
+				WithTimeout(apiTimeout))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		actionRequest = resp.GetPayload()
+	}
+
+	d.SetId(actionRequest.ID.String())
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:      5 * time.Second,
+		Pending:    []string{models.RequestStatusCREATED, models.RequestStatusPENDING, models.RequestStatusINITIALIZATION, models.RequestStatusCHECKINGAPPROVAL, models.RequestStatusAPPROVALPENDING, models.RequestStatusINPROGRESS, models.RequestStatusCOMPLETION},
+		Refresh:    deploymentRequestStatusRefreshFunc(*apiClient, actionRequest.ID, apiTimeout),
+		Target:     []string{models.RequestStatusSUCCESSFUL},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 5 * time.Second,
+	}
+
+	// The request is kept in the state even if it fails, so that it is tainted and the action runs again on next apply
+	if _, err := stateChangeFunc.WaitForStateContext(ctx); err != nil {
+		readErrors := resourceDeploymentActionRead(ctx, d, m)
+		if readErrors.HasError() {
+			return append(readErrors, diag.Errorf("action %s failed on deployment %s: %v", actionID, deploymentUUID, err.Error())...)
+		}
+		return diag.Errorf("action %s failed on deployment %s: %v", actionID, deploymentUUID, err.Error())
+	}
+
+	log.Printf("Finished to create vra_deployment_action resource with action %s", actionID)
+	return resourceDeploymentActionRead(ctx, d, m)
+}
+
+// getDeploymentActionInputs converts the configured inputs to the types defined in the schema of the deployment or
+// resource action
+func getDeploymentActionInputs(apiClient *client.MulticloudIaaS, deploymentUUID, resourceUUID strfmt.UUID, actionID string, configInputs map[string]interface{}) (map[string]interface{}, error) {
+	if len(configInputs) == 0 {
+		return make(map[string]interface{}), nil
+	}
+
+	var actionInputTypesMap map[string]string
+	var err error
+	if resourceUUID != "" {
+		actionInputTypesMap, err = getResourceActionInputTypesMap(apiClient, deploymentUUID, resourceUUID, actionID)
+	} else {
+		actionInputTypesMap, err = getDeploymentActionInputTypesMap(apiClient, deploymentUUID, actionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := getInputsByType(configInputs, actionInputTypesMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create action inputs for %v. %v", actionID, err.Error())
+	}
+	return inputs, nil
+}
+
+func getResourceActionInputTypesMap(apiClient *client.MulticloudIaaS, deploymentUUID, resourceUUID strfmt.UUID, actionID string) (map[string]string, error) {
+	log.Printf("Getting the schema for deploymentID: %v, resourceID: %v, actionID: %v", deploymentUUID, resourceUUID, actionID)
+	resourceAction, err := apiClient.DeploymentActions.GetResourceActionUsingGET(deployment_actions.
+		NewGetResourceActionUsingGETParams().WithDeploymentID(deploymentUUID).WithResourceID(resourceUUID).WithActionID(actionID))
+	if err != nil {
+		return nil, err
+	}
+
+	actionSchema, ok := resourceAction.GetPayload().Schema.(map[string]interface{})
+	if !ok || actionSchema["properties"] == nil {
+		return make(map[string]string), nil
+	}
+	return getInputTypesMapFromSchema(actionSchema["properties"].(map[string]interface{}))
+}
+
+func deploymentRequestStatusRefreshFunc(apiClient client.MulticloudIaaS, requestID strfmt.UUID, timeout time.Duration) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ret, err := apiClient.Requests.GetRequestUsingGET(
+			requests.NewGetRequestUsingGETParams().
+				WithRequestID(requestID).
+				WithAPIVersion(withString(DeploymentsAPIVersion)).
+				WithTimeout(timeout))
+		if err != nil {
+			return "", models.RequestStatusFAILED, err
+		}
+
+		status := ret.Payload.Status
+		switch status {
+		case models.RequestStatusCREATED, models.RequestStatusPENDING, models.RequestStatusINITIALIZATION, models.RequestStatusCHECKINGAPPROVAL, models.RequestStatusAPPROVALPENDING, models.RequestStatusINPROGRESS, models.RequestStatusCOMPLETION:
+			return [...]string{requestID.String()}, status, nil
+		case models.RequestStatusAPPROVALREJECTED, models.RequestStatusABORTED, models.RequestStatusFAILED:
+			return [...]string{requestID.String()}, status, fmt.Errorf("request %s: %s", status, ret.Payload.Details)
+		case models.RequestStatusSUCCESSFUL:
+			return requestID.String(), status, nil
+		default:
+			return [...]string{requestID.String()}, status, fmt.Errorf("deploymentRequestStatusRefreshFunc: unknown status %v", status)
+		}
+	}
+}
+
+func resourceDeploymentActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("Reading the vra_deployment_action resource %s", d.Id())
+	apiClient := m.(*Client).apiClient
+
+	resp, err := apiClient.Requests.GetRequestUsingGET(
+		requests.NewGetRequestUsingGETParams().
+			WithRequestID(strfmt.UUID(d.Id())).
+			WithAPIVersion(withString(DeploymentsAPIVersion)).
+			WithTimeout(m.(*Client).apiTimeout))
+	if err != nil {
+		switch err.(type) {
+		case *requests.GetRequestUsingGETNotFound:
+			// The action already ran and cannot be undone, so the state is kept even if the request has been purged
+			log.Printf("[WARN] request %s of vra_deployment_action not found, keeping the last known state", d.Id())
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	actionRequest := resp.Payload
+	if completedAt := time.Time(actionRequest.CompletedAt); !completedAt.IsZero() {
+		d.Set("completed_at", actionRequest.CompletedAt.String())
+	}
+	if actionRequest.CreatedAt != nil {
+		d.Set("created_at", actionRequest.CreatedAt.String())
+	}
+	d.Set("details", actionRequest.Details)
+	d.Set("name", actionRequest.Name)
+	d.Set("requested_by", actionRequest.RequestedBy)
+	d.Set("status", actionRequest.Status)
+
+	if err := d.Set("outputs", expandInputsToString(actionRequest.Outputs)); err != nil {
+		return diag.Errorf("error setting vra_deployment_action outputs - error: %#v", err)
+	}
+
+	log.Printf("Finished reading the vra_deployment_action resource %s", d.Id())
+	return nil
+}
+
+func resourceDeploymentActionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// A day-2 action cannot be undone, so the request is only removed from the state
+	log.Printf("Removing the vra_deployment_action resource %s from the state", d.Id())
+	d.SetId("")
+	return nil
+}
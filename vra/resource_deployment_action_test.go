@@ -0,0 +1,87 @@
+package vra
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const testDeploymentActionDeploymentID = "2f3f3cbd-1e4f-4b3c-9a9e-23a5f1a0c7f1"
+const testDeploymentActionResourceID = "6b7e4bd4-8ca3-4c56-b8a1-3d0d0c6b0b0e"
+const testDeploymentActionRequestID = "8a1c6c5e-9f0d-4d4e-a5b6-0d2c7c9a1e55"
+
+func TestResourceDeploymentAction_Create(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+
+	resourcePath := "/deployment/api/deployments/" + testDeploymentActionDeploymentID + "/resources/" + testDeploymentActionResourceID
+	api.handle(http.MethodGet, resourcePath+"/actions/Cloud.vSphere.Machine.Resize", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "Cloud.vSphere.Machine.Resize", "valid": true,
+			"schema": map[string]interface{}{"properties": map[string]interface{}{
+				"cpuCount":      map[string]interface{}{"type": "integer"},
+				"rebootMachine": map[string]interface{}{"type": "boolean"},
+			}}})
+	})
+	api.handle(http.MethodPost, resourcePath+"/requests", func(w http.ResponseWriter, r *http.Request) {
+		request := api.readJSON(r)
+		if request["actionId"] != "Cloud.vSphere.Machine.Resize" {
+			t.Errorf("expected the Cloud.vSphere.Machine.Resize action, got %v", request["actionId"])
+		}
+		inputs, _ := request["inputs"].(map[string]interface{})
+		if inputs["cpuCount"] != float64(4) || inputs["rebootMachine"] != true {
+			t.Errorf("expected the inputs to be converted to their types, got %#v", inputs)
+		}
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": testDeploymentActionRequestID, "name": "Resize",
+			"status": "INPROGRESS", "requestedBy": "admin", "createdAt": "2021-06-01T10:00:00.000Z", "completedTasks": 0, "totalTasks": 1,
+			"resourceIds": []interface{}{testDeploymentActionResourceID}})
+	})
+
+	status := "SUCCESSFUL"
+	api.handle(http.MethodGet, "/deployment/api/requests/"+testDeploymentActionRequestID, func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": testDeploymentActionRequestID, "name": "Resize",
+			"status": status, "details": "resize failed", "requestedBy": "admin", "createdAt": "2021-06-01T10:00:00.000Z",
+			"completedTasks": 1, "totalTasks": 1, "resourceIds": []interface{}{testDeploymentActionResourceID},
+			"outputs": map[string]interface{}{"cpuCount": 4}})
+	})
+
+	r := resourceDeploymentAction()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"action_id":     "Cloud.vSphere.Machine.Resize",
+		"deployment_id": testDeploymentActionDeploymentID,
+		"resource_id":   testDeploymentActionResourceID,
+		"inputs": map[string]interface{}{
+			"cpuCount":      "4",
+			"rebootMachine": "true",
+		},
+	})
+
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned error %v", diags)
+	}
+	if d.Id() != testDeploymentActionRequestID {
+		t.Errorf("expected the id of the action request %s, got %q", testDeploymentActionRequestID, d.Id())
+	}
+	if d.Get("status").(string) != "SUCCESSFUL" || d.Get("outputs").(map[string]interface{})["cpuCount"] != "4" {
+		t.Errorf("unexpected status %v and outputs %v", d.Get("status"), d.Get("outputs"))
+	}
+
+	// A failed request fails the create, but is kept in the state
+	status = "FAILED"
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"action_id":     "Cloud.vSphere.Machine.Resize",
+		"deployment_id": testDeploymentActionDeploymentID,
+		"resource_id":   testDeploymentActionResourceID,
+		"inputs": map[string]interface{}{
+			"cpuCount":      "4",
+			"rebootMachine": "true",
+		},
+	})
+	if diags := r.CreateContext(context.Background(), d, c); !diags.HasError() {
+		t.Fatalf("expected create to return an error for a failed request")
+	}
+	if d.Id() != testDeploymentActionRequestID || d.Get("status").(string) != "FAILED" {
+		t.Errorf("expected the failed request to be kept in the state, got id %q and status %v", d.Id(), d.Get("status"))
+	}
+}
@@ -1,18 +1,27 @@
 package vra
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/vmware/vra-sdk-go/pkg/client/deployments"
+	"github.com/vmware/vra-sdk-go/pkg/models"
 
 	"os"
+	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -36,10 +45,36 @@ func TestAccVRADeployment_CatalogItem(t *testing.T) {
 					resource.TestCheckResourceAttrPair(resource1, "catalog_item_id", catalogItem, "id"),
 				),
 			},
+			{
+				ResourceName:      resource1,
+				ImportState:       true,
+				ImportStateIdFunc: testAccVRADeploymentImportStateIDFunc(resource1),
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"expand_last_request",
+					"expand_project",
+					"expand_resources",
+					"inputs",
+					"last_request",
+					"lease_renewal_threshold_days",
+				},
+			},
 		},
 	})
 }
 
+// testAccVRADeploymentImportStateIDFunc imports the deployment by "<project_id>/<name>"
+func testAccVRADeploymentImportStateIDFunc(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.Attributes["name"]), nil
+	}
+}
+
 func TestAccVRADeployment_Empty(t *testing.T) {
 	rInt := acctest.RandInt()
 	resource1 := "vra_deployment.this"
@@ -279,3 +314,450 @@ func testAccCheckVRADeploymentUpdateConfig(rInt int) string {
 	  }
 	}`, projectName, catalogItemName, rInt)
 }
+
+func TestDeploymentLeaseNeedsRenewal(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		leaseExpireAt string
+		thresholdDays int
+		expected      bool
+	}{
+		{"", 1, false},
+		{"not-a-date", 1, false},
+		{"2021-06-10T12:00:00Z", 1, false},
+		{"2021-06-02T06:00:00Z", 1, true},
+		{"2021-06-05T12:00:00Z", 7, true},
+		{"2021-05-31T12:00:00Z", 0, true},
+	}
+
+	for _, c := range cases {
+		if actual := deploymentLeaseNeedsRenewal(c.leaseExpireAt, c.thresholdDays, now); actual != c.expected {
+			t.Errorf("lease expiring at %q with a threshold of %d days: expected renewal %t, got %t", c.leaseExpireAt, c.thresholdDays, c.expected, actual)
+		}
+	}
+}
+
+func TestSuppressEqualLeaseExpireAt(t *testing.T) {
+	cases := []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		{"2021-12-31T00:00:00.000Z", "2021-12-31T00:00:00Z", true},
+		{"2021-12-31T00:00:00.000Z", "2021-12-31T01:00:00+01:00", true},
+		{"2021-12-31T00:00:00.000Z", "2022-01-31T00:00:00Z", false},
+		{"", "2021-12-31T00:00:00Z", false},
+	}
+
+	for _, c := range cases {
+		if actual := suppressEqualLeaseExpireAt("lease_expire_at", c.old, c.new, nil); actual != c.expected {
+			t.Errorf("lease expiring at %q changed to %q: expected suppressed diff %t, got %t", c.old, c.new, c.expected, actual)
+		}
+	}
+}
+
+func TestParseDeploymentImportID(t *testing.T) {
+	cases := []struct {
+		id      string
+		project string
+		name    string
+		ok      bool
+	}{
+		{"05956583-6488-4e7d-84c9-92a7b7219a15", "", "", false},
+		{"my-project/my-deployment", "my-project", "my-deployment", true},
+		{"my-project/my/deployment", "my-project", "my/deployment", true},
+		{"/my-deployment", "", "", false},
+		{"my-project/", "", "", false},
+	}
+
+	for _, c := range cases {
+		project, name, ok := parseDeploymentImportID(c.id)
+		if project != c.project || name != c.name || ok != c.ok {
+			t.Errorf("parsing %q: expected (%q, %q, %t), got (%q, %q, %t)", c.id, c.project, c.name, c.ok, project, name, ok)
+		}
+	}
+}
+
+func TestDeploymentIDByName(t *testing.T) {
+	deploymentList := []*models.Deployment{
+		{ID: strfmt.UUID("11111111-1111-1111-1111-111111111111"), Name: withString("web")},
+		{ID: strfmt.UUID("22222222-2222-2222-2222-222222222222"), Name: withString("web-2")},
+		{ID: strfmt.UUID("33333333-3333-3333-3333-333333333333"), Name: withString("db")},
+		{ID: strfmt.UUID("44444444-4444-4444-4444-444444444444"), Name: withString("db")},
+	}
+
+	id, err := deploymentIDByName(deploymentList, "my-project", "web")
+	if err != nil || id != "11111111-1111-1111-1111-111111111111" {
+		t.Errorf("expected the id of deployment web, got %q, %v", id, err)
+	}
+
+	if _, err := deploymentIDByName(deploymentList, "my-project", "app"); err == nil {
+		t.Error("expected an error for a deployment name without a match")
+	}
+
+	_, err = deploymentIDByName(deploymentList, "my-project", "db")
+	if err == nil || !regexp.MustCompile("2 deployments named db").MatchString(err.Error()) {
+		t.Errorf("expected an ambiguity error for deployment db, got %v", err)
+	}
+}
+
+func TestResolveProjectID(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+
+	var filters []string
+	api.handle(http.MethodGet, "/iaas/api/projects", func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("$filter")
+		filters = append(filters, filter)
+
+		switch filter {
+		case "name eq 'O''Brien'":
+			api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": []interface{}{map[string]interface{}{"id": "project-1", "name": "O'Brien"}}, "totalElements": 1})
+		case "name eq 'shared'":
+			// The second match is on another page
+			api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": []interface{}{map[string]interface{}{"id": "project-2", "name": "shared"}}, "totalElements": 2})
+		default:
+			api.writeJSON(w, http.StatusOK, map[string]interface{}{"content": []interface{}{}, "totalElements": 0})
+		}
+	})
+
+	id, err := resolveProjectID(c.apiClient, "O'Brien", c.apiTimeout)
+	if err != nil || id != "project-1" {
+		t.Errorf("expected the id of project O'Brien, got %q, %v", id, err)
+	}
+	if len(filters) != 1 || filters[0] != "name eq 'O''Brien'" {
+		t.Errorf("expected the quote of the project name to be escaped, got filters %v", filters)
+	}
+
+	if _, err := resolveProjectID(c.apiClient, "shared", c.apiTimeout); err == nil || !strings.Contains(err.Error(), "more than one project") {
+		t.Errorf("expected an ambiguity error for project shared, got %v", err)
+	}
+
+	if _, err := resolveProjectID(c.apiClient, "missing", c.apiTimeout); err == nil {
+		t.Error("expected an error for a project name without a match")
+	}
+
+	id, err = resolveProjectID(c.apiClient, "5b2f1a1e-8c1d-4a5e-9f3a-2d1c0b9a8e7f", c.apiTimeout)
+	if err != nil || id != "5b2f1a1e-8c1d-4a5e-9f3a-2d1c0b9a8e7f" || len(filters) != 3 {
+		t.Errorf("expected a project id to be used as is, got %q, %v", id, err)
+	}
+}
+
+func TestDeploymentInputsWithoutDefaults(t *testing.T) {
+	inputsSchema := &models.PropertyDefinition{Properties: map[string]models.PropertyDefinition{
+		"count":  {Type: "integer", Default: float64(1)},
+		"flavor": {Type: "string", Default: "small"},
+		"image":  {Type: "string"},
+	}}
+
+	inputs := deploymentInputsWithoutDefaults(map[string]interface{}{
+		"count":  float64(1),
+		"flavor": "large",
+		"image":  "ubuntu",
+		"extra":  "value",
+	}, inputsSchema)
+
+	expected := map[string]interface{}{"flavor": "large", "image": "ubuntu", "extra": "value"}
+	if !reflect.DeepEqual(inputs, expected) {
+		t.Errorf("expected the inputs without defaults %v, got %v", expected, inputs)
+	}
+}
+
+func TestValidateDeploymentInputs(t *testing.T) {
+	// A catalog item inputs schema with required and enum inputs
+	rawSchema := `{
+		"type": "object",
+		"required": ["image", "size", "count"],
+		"properties": {
+			"image": {"type": "string"},
+			"size": {"type": "string", "enum": ["small", "medium", "large"]},
+			"count": {"type": "integer", "default": 1},
+			"cpu": {"type": "integer", "enum": [1, 2, 4]},
+			"monitored": {"type": "boolean"}
+		}
+	}`
+	inputsSchema := &models.PropertyDefinition{}
+	if err := json.Unmarshal([]byte(rawSchema), inputsSchema); err != nil {
+		t.Fatalf("unable to parse the inputs schema: %s", err)
+	}
+
+	var tests = []struct {
+		name     string
+		inputs   map[string]interface{}
+		problems []string
+	}{
+		{
+			"valid inputs",
+			map[string]interface{}{"image": "ubuntu", "size": "small", "cpu": "2", "monitored": "true", "extra": "ignored"},
+			nil,
+		},
+		{
+			"missing required input",
+			map[string]interface{}{"size": "small"},
+			[]string{"inputs.image: the input is required"},
+		},
+		{
+			"value not allowed",
+			map[string]interface{}{"image": "ubuntu", "size": "huge", "cpu": "3"},
+			[]string{
+				`inputs.cpu: "3" is not one of the allowed values 1, 2, 4`,
+				`inputs.size: "huge" is not one of the allowed values small, medium, large`,
+			},
+		},
+		{
+			"value of the wrong type",
+			map[string]interface{}{"image": "ubuntu", "size": "small", "count": "two", "monitored": "maybe"},
+			[]string{
+				`inputs.count: "two" is not a valid integer`,
+				`inputs.monitored: "maybe" is not a valid boolean`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		err := validateDeploymentInputs(tt.inputs, inputsSchema)
+		if len(tt.problems) == 0 {
+			if err != nil {
+				t.Errorf("%s: expected no error, got %s", tt.name, err)
+			}
+			continue
+		}
+
+		expected := "invalid deployment inputs:\n" + strings.Join(tt.problems, "\n")
+		if err == nil || err.Error() != expected {
+			t.Errorf("%s: expected error %q, got %v", tt.name, expected, err)
+		}
+	}
+}
+
+func TestResourceDeployment_BlueprintContent(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceDeployment()
+
+	const deploymentID = "b0b0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6"
+	content := "formatVersion: 1\nresources:\n  Cloud_Machine_1:\n    type: Cloud.Machine\n    properties:\n      image: ubuntu\n      flavor: small\n"
+
+	var requested map[string]interface{}
+	api.handle(http.MethodPost, "/blueprint/api/blueprint-requests", func(w http.ResponseWriter, r *http.Request) {
+		requested = api.readJSON(r)
+		api.writeJSON(w, http.StatusAccepted, map[string]interface{}{
+			"id":           "request-1",
+			"deploymentId": deploymentID,
+			"status":       "STARTED",
+		})
+	})
+	api.handle(http.MethodGet, "/deployment/api/deployments/"+deploymentID, func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":        deploymentID,
+			"name":      "inline",
+			"projectId": "project-1",
+			"status":    models.DeploymentStatusCREATESUCCESSFUL,
+			"resources": []interface{}{
+				map[string]interface{}{
+					"id":         "c0c0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6",
+					"name":       "Cloud_Machine_1",
+					"type":       "Cloud.Machine",
+					"properties": map[string]interface{}{"image": "ubuntu"},
+				},
+			},
+		})
+	})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":              "inline",
+		"project_id":        "project-1",
+		"blueprint_content": content,
+	})
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned errors %#v", diags)
+	}
+
+	if requested["content"] != content || requested["blueprintId"] != nil {
+		t.Errorf("expected the blueprint content to be requested inline, got %v", requested)
+	}
+	if d.Id() != deploymentID {
+		t.Errorf("expected deployment id %s, got %s", deploymentID, d.Id())
+	}
+	if d.Get("status") != models.DeploymentStatusCREATESUCCESSFUL {
+		t.Errorf("expected status %s, got %s", models.DeploymentStatusCREATESUCCESSFUL, d.Get("status"))
+	}
+
+	resources := d.Get("resources").(*schema.Set).List()
+	if len(resources) != 1 || resources[0].(map[string]interface{})["name"] != "Cloud_Machine_1" {
+		t.Errorf("expected the resource Cloud_Machine_1, got %v", resources)
+	}
+}
+
+func TestValidateBlueprintContent(t *testing.T) {
+	if _, errs := validateBlueprintContent("formatVersion: 1\nresources: {}\n", "blueprint_content"); len(errs) != 0 {
+		t.Errorf("expected valid content to pass validation, got %v", errs)
+	}
+
+	_, errs := validateBlueprintContent("resources:\n  - a\n b: [\n", "blueprint_content")
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `"blueprint_content" is not valid YAML`) {
+		t.Errorf("expected invalid content to fail validation, got %v", errs)
+	}
+}
+
+func TestChangeCreatedDeploymentOwner(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceDeployment()
+
+	const deploymentID = "d0d0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6"
+	deploymentPath := "/deployment/api/deployments/" + deploymentID
+
+	var newOwners []interface{}
+	api.handle(http.MethodGet, deploymentPath, func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":          deploymentID,
+			"name":        "handed-over",
+			"ownedBy":     "svc-terraform",
+			"lastRequest": map[string]interface{}{"id": "e0d0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6", "status": models.RequestStatusSUCCESSFUL},
+		})
+	})
+	api.handle(http.MethodGet, deploymentPath+"/actions", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, []interface{}{map[string]interface{}{"id": "Deployment.ChangeOwner", "valid": true}})
+	})
+	api.handle(http.MethodGet, deploymentPath+"/actions/Deployment.ChangeOwner", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "Deployment.ChangeOwner", "valid": true,
+			"schema": map[string]interface{}{"properties": map[string]interface{}{"New Owner": map[string]interface{}{"type": "string"}}}})
+	})
+	api.handle(http.MethodPost, deploymentPath+"/requests", func(w http.ResponseWriter, r *http.Request) {
+		request := api.readJSON(r)
+		inputs, _ := request["inputs"].(map[string]interface{})
+		newOwners = append(newOwners, inputs["New Owner"])
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "e0d0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6", "status": models.RequestStatusINPROGRESS})
+	})
+
+	// The requesting user already owns the deployment
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "handed-over", "project_id": "project-1", "owner": "SVC-terraform"})
+	if err := changeCreatedDeploymentOwner(context.Background(), d, c, c.apiClient, strfmt.UUID(deploymentID), "SVC-terraform"); err != nil {
+		t.Fatalf("change owner returned error %s", err)
+	}
+	if len(newOwners) != 0 {
+		t.Fatalf("expected no Change Owner action for the current owner, got %v", newOwners)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "handed-over", "project_id": "project-1", "owner": "jdoe"})
+	if err := changeCreatedDeploymentOwner(context.Background(), d, c, c.apiClient, strfmt.UUID(deploymentID), "jdoe"); err != nil {
+		t.Fatalf("change owner returned error %s", err)
+	}
+	if len(newOwners) != 1 || newOwners[0] != "jdoe" {
+		t.Errorf("expected a Change Owner action to jdoe, got %v", newOwners)
+	}
+}
+
+func TestResourceDeployment_CreateLeaseFailure(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceDeployment()
+
+	const deploymentID = "f0d0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6"
+	deploymentPath := "/deployment/api/deployments/" + deploymentID
+	leaseExpireAt := time.Now().UTC().AddDate(0, 0, 30).Format(time.RFC3339)
+
+	api.handle(http.MethodPost, "/blueprint/api/blueprint-requests", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "request-1", "deploymentId": deploymentID, "status": "STARTED"})
+	})
+	api.handle(http.MethodGet, deploymentPath, func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":            deploymentID,
+			"name":          "leased",
+			"projectId":     "project-1",
+			"ownedBy":       "svc-terraform",
+			"leaseExpireAt": leaseExpireAt,
+			"status":        models.DeploymentStatusCREATESUCCESSFUL,
+		})
+	})
+	api.handle(http.MethodGet, deploymentPath+"/actions", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, []interface{}{map[string]interface{}{"id": "Deployment.ChangeLease", "valid": false}})
+	})
+
+	config := map[string]interface{}{
+		"name":              "leased",
+		"project_id":        "project-1",
+		"blueprint_content": "formatVersion: 1\nresources: {}\n",
+		"lease_days":        90,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	diags := r.CreateContext(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatalf("expected the failed lease change not to fail the create, got %#v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "lease") {
+		t.Errorf("expected a warning about the lease, got %#v", diags)
+	}
+	if d.Id() != deploymentID {
+		t.Fatalf("expected the deployment %s to be kept, got %q", deploymentID, d.Id())
+	}
+
+	// The next plan changes the lease again
+	instanceDiff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatalf("diff returned error %s", err)
+	}
+	if instanceDiff == nil || instanceDiff.Attributes["lease_days"] == nil || instanceDiff.Attributes["lease_days"].New != "90" {
+		t.Errorf("expected the next plan to change lease_days, got %#v", instanceDiff)
+	}
+	if instanceDiff != nil && instanceDiff.RequiresNew() {
+		t.Errorf("expected the next plan to update the deployment in place")
+	}
+}
+
+func TestResourceDeployment_CreateOwnerFailure(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+	r := resourceDeployment()
+
+	const deploymentID = "f1d0a5e4-1b2c-4d5e-8f90-a1b2c3d4e5f6"
+	deploymentPath := "/deployment/api/deployments/" + deploymentID
+
+	api.handle(http.MethodPost, "/blueprint/api/blueprint-requests", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "request-1", "deploymentId": deploymentID, "status": "STARTED"})
+	})
+	api.handle(http.MethodGet, deploymentPath, func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":        deploymentID,
+			"name":      "handed-over",
+			"projectId": "project-1",
+			"ownedBy":   "svc-terraform",
+			"status":    models.DeploymentStatusCREATESUCCESSFUL,
+		})
+	})
+	api.handle(http.MethodGet, deploymentPath+"/actions", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, []interface{}{map[string]interface{}{"id": "Deployment.ChangeOwner", "valid": false}})
+	})
+
+	config := map[string]interface{}{
+		"name":              "handed-over",
+		"project_id":        "project-1",
+		"blueprint_content": "formatVersion: 1\nresources: {}\n",
+		"owner":             "jdoe",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	diags := r.CreateContext(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatalf("expected the failed owner change not to fail the create, got %#v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "owner") {
+		t.Errorf("expected a warning about the owner, got %#v", diags)
+	}
+	if d.Id() != deploymentID {
+		t.Fatalf("expected the deployment %s to be kept, got %q", deploymentID, d.Id())
+	}
+	if d.Get("owner") != "svc-terraform" {
+		t.Errorf("expected the actual owner svc-terraform to be read, got %v", d.Get("owner"))
+	}
+
+	// The next plan changes the owner again
+	instanceDiff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), c)
+	if err != nil {
+		t.Fatalf("diff returned error %s", err)
+	}
+	if instanceDiff == nil || instanceDiff.Attributes["owner"] == nil || instanceDiff.Attributes["owner"].New != "jdoe" {
+		t.Errorf("expected the next plan to change owner, got %#v", instanceDiff)
+	}
+}
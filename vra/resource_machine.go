@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/compute"
 	"github.com/vmware/vra-sdk-go/pkg/client/disk"
@@ -92,6 +95,16 @@ func resourceMachine() *schema.Resource {
 							Required:    true,
 							Description: "The id of the existing block device.",
 						},
+						"scsi_controller": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The SCSI controller to attach the block device to, for example SCSI_Controller_0.",
+						},
+						"unit_number": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The unit number of the block device on the SCSI controller, for example 2.",
+						},
 					},
 				},
 			},
@@ -165,8 +178,15 @@ func resourceMachine() *schema.Resource {
 				Computed: true,
 			},
 			"power_state": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The desired power state of the machine, one of ON, OFF or SUSPEND. Once set, a change powers the machine on, off or suspends it.",
+				ValidateFunc: validation.StringInSlice([]string{models.MachinePowerStateON, models.MachinePowerStateOFF, models.MachinePowerStateSUSPEND}, false),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// A machine shut down from the guest OS is off
+					return old == models.MachinePowerStateGUESTOFF && new == models.MachinePowerStateOFF
+				},
 			},
 			"project_id": {
 				Type:     schema.TypeString,
@@ -294,6 +314,12 @@ func resourceMachineCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 
+	if v, ok := d.GetOk("power_state"); ok {
+		if err := changeMachinePowerState(ctx, apiClient, machineID, v.(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceMachineRead(ctx, d, m)
 }
 
@@ -351,7 +377,7 @@ func resourceMachineRead(ctx context.Context, d *schema.ResourceData, m interfac
 	d.Set("updated_at", machine.UpdatedAt)
 	d.Set("owner", machine.Owner)
 	d.Set("organization_id", machine.OrganizationID)
-	d.Set("custom_properties", machine.CustomProperties)
+	d.Set("custom_properties", flattenCustomProperties(d.Get("custom_properties").(map[string]interface{}), machine.CustomProperties))
 
 	if image, found := machine.CustomProperties["image"]; found {
 		d.Set("image", image)
@@ -416,6 +442,14 @@ func resourceMachineUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 
+	if d.HasChange("power_state") {
+		if v, ok := d.GetOk("power_state"); ok {
+			if err := changeMachinePowerState(ctx, apiClient, d.Id(), v.(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	log.Printf("finished updating the vra_machine resource with name %s", d.Get("name"))
 	return resourceMachineRead(ctx, d, m)
 }
@@ -438,22 +472,7 @@ func attachAndDetachDisks(ctx context.Context, d *schema.ResourceData, apiClient
 	for i, diskToDetach := range disksToDetach {
 		diskID := diskToDetach["block_device_id"].(string)
 		log.Printf("Detaching the disk %v of %v (disk id: %v) from vra_machine resource %v", i+1, len(disksToDetach), diskID, d.Get("name"))
-		deleteMachineDiskAccepted, err := apiClient.Disk.DeleteMachineDisk(disk.NewDeleteMachineDiskParams().WithID(id).WithId1(diskID))
-
-		if err != nil {
-			return err
-		}
-
-		stateChangeFunc := resource.StateChangeConf{
-			Delay:      5 * time.Second,
-			Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-			Refresh:    machineStateRefreshFunc(*apiClient, *deleteMachineDiskAccepted.Payload.ID),
-			Target:     []string{models.RequestTrackerStatusFINISHED},
-			Timeout:    d.Timeout(schema.TimeoutCreate),
-			MinTimeout: 5 * time.Second,
-		}
-
-		if _, err := stateChangeFunc.WaitForStateContext(ctx); err != nil {
+		if err := detachMachineDisk(ctx, apiClient, id, diskID, d.Timeout(schema.TimeoutCreate)); err != nil {
 			return err
 		}
 	}
@@ -472,7 +491,11 @@ func attachAndDetachDisks(ctx context.Context, d *schema.ResourceData, apiClient
 
 	log.Printf("disks currently attached to machine %v: %v", id, diskIds)
 
-	// attach the disks one by one
+	// attach the disks one by one, in the order of their SCSI controller and unit number
+	sort.SliceStable(disksToAttach, func(i, j int) bool {
+		return diskAttachmentLess(disksToAttach[i]["scsi_controller"].(string), disksToAttach[i]["unit_number"].(string),
+			disksToAttach[j]["scsi_controller"].(string), disksToAttach[j]["unit_number"].(string))
+	})
 	for i, diskToAttach := range disksToAttach {
 		diskID := diskToAttach["block_device_id"].(string)
 		log.Printf("Attaching the disk %v of %v (disk id: %v) to vra_machine resource %v", i+1, len(diskToAttach), diskID, d.Get("name"))
@@ -480,9 +503,11 @@ func attachAndDetachDisks(ctx context.Context, d *schema.ResourceData, apiClient
 		// attach the disk if it's not already attached to machine
 		if index, _ := indexOf(diskID, diskIds); index == -1 {
 			diskAttachmentSpecification := models.DiskAttachmentSpecification{
-				BlockDeviceID: withString(diskID),
-				Description:   diskToAttach["description"].(string),
-				Name:          diskToAttach["name"].(string),
+				BlockDeviceID:  withString(diskID),
+				Description:    diskToAttach["description"].(string),
+				Name:           diskToAttach["name"].(string),
+				ScsiController: diskToAttach["scsi_controller"].(string),
+				UnitNumber:     diskToAttach["unit_number"].(string),
 			}
 
 			attachMachineDiskOk, err := apiClient.Disk.AttachMachineDisk(disk.NewAttachMachineDiskParams().WithID(id).WithBody(&diskAttachmentSpecification))
@@ -580,11 +605,98 @@ func resizeMachine(ctx context.Context, d *schema.ResourceData, apiClient *clien
 	return nil
 }
 
+// changeMachinePowerState powers the machine on, off or suspends it, unless it is already in the desired power state
+func changeMachinePowerState(ctx context.Context, apiClient *client.MulticloudIaaS, id, powerState string, timeout time.Duration) error {
+	getResp, err := apiClient.Compute.GetMachine(compute.NewGetMachineParams().WithID(id))
+	if err != nil {
+		return err
+	}
+
+	current := ""
+	if getResp.Payload.PowerState != nil {
+		current = *getResp.Payload.PowerState
+	}
+	if current == powerState || (current == models.MachinePowerStateGUESTOFF && powerState == models.MachinePowerStateOFF) {
+		return nil
+	}
+
+	log.Printf("changing the power state of machine %s from %s to %s", id, current, powerState)
+	var requestTracker *models.RequestTracker
+	switch powerState {
+	case models.MachinePowerStateON:
+		resp, err := apiClient.Compute.PowerOnMachine(compute.NewPowerOnMachineParams().WithID(id))
+		if err != nil {
+			return err
+		}
+		requestTracker = resp.Payload
+	case models.MachinePowerStateOFF:
+		resp, err := apiClient.Compute.PowerOffMachine(compute.NewPowerOffMachineParams().WithID(id))
+		if err != nil {
+			return err
+		}
+		requestTracker = resp.Payload
+	case models.MachinePowerStateSUSPEND:
+		resp, err := apiClient.Compute.SuspendMachine(compute.NewSuspendMachineParams().WithID(id))
+		if err != nil {
+			return err
+		}
+		requestTracker = resp.Payload
+	default:
+		return fmt.Errorf("unsupported machine power state %s", powerState)
+	}
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:      5 * time.Second,
+		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
+		Refresh:    machineStateRefreshFunc(*apiClient, *requestTracker.ID),
+		Target:     []string{models.RequestTrackerStatusFINISHED},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateChangeFunc.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error changing the power state of machine %s to %s: %s", id, powerState, err)
+	}
+
+	log.Printf("finished changing the power state of machine %s to %s", id, powerState)
+	return nil
+}
+
+// detachMachineDisk detaches the block device from the machine, without deleting it
+func detachMachineDisk(ctx context.Context, apiClient *client.MulticloudIaaS, machineID, diskID string, timeout time.Duration) error {
+	deleteMachineDiskAccepted, err := apiClient.Disk.DeleteMachineDisk(disk.NewDeleteMachineDiskParams().WithID(machineID).WithId1(diskID))
+	if err != nil {
+		return err
+	}
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:      5 * time.Second,
+		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
+		Refresh:    machineStateRefreshFunc(*apiClient, *deleteMachineDiskAccepted.Payload.ID),
+		Target:     []string{models.RequestTrackerStatusFINISHED},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err = stateChangeFunc.WaitForStateContext(ctx)
+	return err
+}
+
 func resourceMachineDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("Starting to delete the vra_machine resource with name %s", d.Get("name"))
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
+
+	// Detach the block devices attached with the disks attribute first, so they are not deleted with the machine
+	for _, configDisk := range d.Get("disks").(*schema.Set).List() {
+		diskID := configDisk.(map[string]interface{})["block_device_id"].(string)
+		log.Printf("Detaching the disk %v from vra_machine resource %v before deleting it", diskID, d.Get("name"))
+		if err := detachMachineDisk(ctx, apiClient, id, diskID, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	deleteMachine, err := apiClient.Compute.DeleteMachine(compute.NewDeleteMachineParams().WithID(id))
 	if err != nil {
 		return diag.FromErr(err)
@@ -626,12 +738,42 @@ func expandDisks(configDisks []interface{}) []*models.DiskAttachmentSpecificatio
 			disk.Description = v
 		}
 
+		if v, ok := diskMap["scsi_controller"].(string); ok && v != "" {
+			disk.ScsiController = v
+		}
+
+		if v, ok := diskMap["unit_number"].(string); ok && v != "" {
+			disk.UnitNumber = v
+		}
+
 		disks = append(disks, &disk)
 	}
 
+	sort.SliceStable(disks, func(i, j int) bool {
+		return diskAttachmentLess(disks[i].ScsiController, disks[i].UnitNumber, disks[j].ScsiController, disks[j].UnitNumber)
+	})
+
 	return disks
 }
 
+// diskAttachmentLess orders the disk attachments by SCSI controller and unit number, so that disks are attached to the
+// lowest units first. Disks without a SCSI controller are attached last, in any order.
+func diskAttachmentLess(controllerA, unitA, controllerB, unitB string) bool {
+	switch {
+	case controllerA == "" || controllerB == "":
+		return controllerA != "" && controllerB == ""
+	case controllerA != controllerB:
+		return controllerA < controllerB
+	}
+
+	a, errA := strconv.Atoi(unitA)
+	b, errB := strconv.Atoi(unitB)
+	if errA == nil && errB == nil {
+		return a < b
+	}
+	return unitA < unitB
+}
+
 func flattenDisks(blockDevices []*models.BlockDevice) []interface{} {
 	if len(blockDevices) == 0 {
 		return make([]interface{}, 0)
@@ -675,6 +817,10 @@ func filterDisks(disksConfig []interface{}, blockDevices []*models.BlockDevice)
 					helper["description"] = blockDevice.Description
 				}
 
+				// The SCSI controller and unit number are only used to attach the block device
+				helper["scsi_controller"] = diskConfigMap["scsi_controller"]
+				helper["unit_number"] = diskConfigMap["unit_number"]
+
 				disks = append(disks, helper)
 				break
 			}
@@ -0,0 +1,248 @@
+package vra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMachineSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMachineSnapshotCreate,
+		ReadContext:   resourceMachineSnapshotRead,
+		UpdateContext: resourceMachineSnapshotUpdate,
+		DeleteContext: resourceMachineSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMachineSnapshotImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"machine_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the machine to snapshot.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A human-friendly description.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "A human-friendly name used as an identifier in APIs that support this option.",
+			},
+			"revert_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value which, when changed to a non-empty value, reverts the machine to this snapshot.",
+			},
+			"snapshot_memory": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Captures the memory of the machine in the snapshot.",
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_current": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"links": linksSchema(),
+			"org_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceMachineSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("Starting to create vra_machine_snapshot resource")
+	apiClient := m.(*Client).apiClient
+
+	machineID := d.Get("machine_id").(string)
+	name := d.Get("name").(string)
+
+	snapshotSpecification := models.SnapshotSpecification{
+		Description:    d.Get("description").(string),
+		Name:           name,
+		SnapshotMemory: d.Get("snapshot_memory").(bool),
+	}
+
+	log.Printf("[DEBUG] create vra_machine_snapshot: %#v", snapshotSpecification)
+	createMachineSnapshotAccepted, err := apiClient.Compute.CreateMachineSnapshot(compute.NewCreateMachineSnapshotParams().WithID(machineID).WithBody(&snapshotSpecification))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForMachineSnapshotRequest(ctx, apiClient, *createMachineSnapshotAccepted.Payload.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshotID, err := findCreatedMachineSnapshot(apiClient, machineID, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(snapshotID)
+
+	log.Printf("Finished to create vra_machine_snapshot resource %s for vra_machine %s", snapshotID, machineID)
+	return resourceMachineSnapshotRead(ctx, d, m)
+}
+
+func waitForMachineSnapshotRequest(ctx context.Context, apiClient *client.MulticloudIaaS, requestID string, timeout time.Duration) error {
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:      5 * time.Second,
+		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
+		Refresh:    machineStateRefreshFunc(*apiClient, requestID),
+		Target:     []string{models.RequestTrackerStatusFINISHED},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err := stateChangeFunc.WaitForStateContext(ctx)
+	return err
+}
+
+// findCreatedMachineSnapshot returns the id of the snapshot with the name, or of the current snapshot of the machine
+// when no name was requested
+func findCreatedMachineSnapshot(apiClient *client.MulticloudIaaS, machineID, name string) (string, error) {
+	resp, err := apiClient.Compute.GetMachineSnapshots(compute.NewGetMachineSnapshotsParams().WithID(machineID))
+	if err != nil {
+		return "", fmt.Errorf("failed to find the created snapshot of machine %s: %s", machineID, err)
+	}
+
+	for _, snapshot := range resp.Payload {
+		if (name != "" && snapshot.Name == name) || (name == "" && snapshot.IsCurrent) {
+			return *snapshot.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to find the created snapshot of machine %s", machineID)
+}
+
+func resourceMachineSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	machineID := d.Get("machine_id").(string)
+	log.Printf("Reading the vra_machine_snapshot resource %s of vra_machine %s", d.Id(), machineID)
+	apiClient := m.(*Client).apiClient
+
+	resp, err := apiClient.Compute.GetMachineSnapshots(compute.NewGetMachineSnapshotsParams().WithID(machineID))
+	if err != nil {
+		switch err.(type) {
+		case *compute.GetMachineSnapshotsNotFound:
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	var snapshot *models.Snapshot
+	for _, s := range resp.Payload {
+		if s.ID != nil && *s.ID == d.Id() {
+			snapshot = s
+			break
+		}
+	}
+	if snapshot == nil {
+		log.Printf("[WARN] snapshot %s of machine %s not found, removing it from state", d.Id(), machineID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("created_at", snapshot.CreatedAt)
+	d.Set("description", snapshot.Description)
+	d.Set("is_current", snapshot.IsCurrent)
+	d.Set("name", snapshot.Name)
+	d.Set("org_id", snapshot.OrgID)
+	d.Set("owner", snapshot.Owner)
+	d.Set("updated_at", snapshot.UpdatedAt)
+
+	if err := d.Set("links", flattenLinks(snapshot.Links)); err != nil {
+		return diag.Errorf("error setting vra_machine_snapshot links - error: %#v", err)
+	}
+
+	log.Printf("Finished reading the vra_machine_snapshot resource %s", d.Id())
+	return nil
+}
+
+func resourceMachineSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("revert_trigger") && d.Get("revert_trigger").(string) != "" {
+		machineID := d.Get("machine_id").(string)
+		log.Printf("Reverting vra_machine %s to vra_machine_snapshot %s", machineID, d.Id())
+		apiClient := m.(*Client).apiClient
+
+		revertMachineSnapshotAccepted, err := apiClient.Compute.RevertMachineSnapshot(compute.NewRevertMachineSnapshotParams().WithMachineID(machineID).WithID(d.Id()))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := waitForMachineSnapshotRequest(ctx, apiClient, *revertMachineSnapshotAccepted.Payload.ID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error reverting machine %s to snapshot %s: %s", machineID, d.Id(), err)
+		}
+	}
+
+	return resourceMachineSnapshotRead(ctx, d, m)
+}
+
+func resourceMachineSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	machineID := d.Get("machine_id").(string)
+	log.Printf("Starting to delete the vra_machine_snapshot %s of vra_machine %s", d.Id(), machineID)
+	apiClient := m.(*Client).apiClient
+
+	deleteMachineSnapshotAccepted, err := apiClient.Compute.DeleteMachineSnapshot(compute.NewDeleteMachineSnapshotParams().WithID(machineID).WithId1(d.Id()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForMachineSnapshotRequest(ctx, apiClient, *deleteMachineSnapshotAccepted.Payload.ID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Printf("Finished deleting the vra_machine_snapshot resource")
+	return nil
+}
+
+// resourceMachineSnapshotImport imports a snapshot using the id "<machine_id>/<snapshot_id>"
+func resourceMachineSnapshotImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of id %q, expected <machine_id>/<snapshot_id>", d.Id())
+	}
+
+	d.Set("machine_id", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
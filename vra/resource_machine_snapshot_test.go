@@ -0,0 +1,82 @@
+package vra
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceMachineSnapshot_Create(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+
+	snapshots := []interface{}{
+		map[string]interface{}{"id": "snapshot-1", "name": "before-upgrade", "isCurrent": false, "_links": map[string]interface{}{}},
+	}
+	api.handle(http.MethodPost, "/iaas/api/machines/machine-1/operations/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		spec := api.readJSON(r)
+		if spec["snapshotMemory"] != true {
+			t.Errorf("expected the snapshot to capture the memory, got %v", spec)
+		}
+		snapshots = append(snapshots, map[string]interface{}{"id": "snapshot-2", "name": spec["name"], "description": spec["description"],
+			"isCurrent": true, "_links": map[string]interface{}{}})
+		api.writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "tracker", "status": "INPROGRESS", "progress": 0, "selfLink": "/iaas/api/request-tracker/tracker"})
+	})
+	api.handle(http.MethodGet, "/iaas/api/request-tracker/", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "tracker", "status": "FINISHED", "progress": 100,
+			"resources": []interface{}{"/iaas/api/machines/machine-1"}, "selfLink": r.URL.Path})
+	})
+	api.handle(http.MethodGet, "/iaas/api/machines/machine-1/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, snapshots)
+	})
+
+	r := resourceMachineSnapshot()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"machine_id":      "machine-1",
+		"name":            "after-upgrade",
+		"description":     "after the upgrade",
+		"snapshot_memory": true,
+	})
+
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned error %v", diags)
+	}
+	if d.Id() != "snapshot-2" {
+		t.Errorf("expected the created snapshot snapshot-2, got %q", d.Id())
+	}
+	if d.Get("is_current").(bool) != true || d.Get("description").(string) != "after the upgrade" {
+		t.Errorf("unexpected snapshot attributes is_current %v, description %v", d.Get("is_current"), d.Get("description"))
+	}
+
+	// A snapshot deleted outside of Terraform is removed from the state
+	snapshots = snapshots[:1]
+	if diags := r.ReadContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read returned error %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the deleted snapshot to be removed from the state, got %q", d.Id())
+	}
+}
+
+func TestResourceMachineSnapshotImport(t *testing.T) {
+	r := resourceMachineSnapshot()
+
+	d := r.TestResourceData()
+	d.SetId("machine-1/snapshot-1")
+	if _, err := resourceMachineSnapshotImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("import returned error %s", err)
+	}
+	if d.Id() != "snapshot-1" || d.Get("machine_id").(string) != "machine-1" {
+		t.Errorf("expected machine_id machine-1 and id snapshot-1, got %q and %q", d.Get("machine_id"), d.Id())
+	}
+
+	for _, id := range []string{"snapshot-1", "machine-1/", "/snapshot-1"} {
+		d := r.TestResourceData()
+		d.SetId(id)
+		if _, err := resourceMachineSnapshotImport(context.Background(), d, nil); err == nil {
+			t.Errorf("expected import of id %q to return an error", id)
+		}
+	}
+}
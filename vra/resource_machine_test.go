@@ -1,17 +1,21 @@
 package vra
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/vmware/vra-sdk-go/pkg/client/compute"
 	"github.com/vmware/vra-sdk-go/pkg/client/flavor_profile"
 	"github.com/vmware/vra-sdk-go/pkg/client/image_profile"
 	"github.com/vmware/vra-sdk-go/pkg/client/location"
 	"github.com/vmware/vra-sdk-go/pkg/client/project"
+	"github.com/vmware/vra-sdk-go/pkg/models"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -273,3 +277,93 @@ resource "vra_flavor_profile" "my-flavor-profile" {
 	}
 }`, name, region, rInt, rInt, rInt, image1, image2, rInt, flavor1, flavor2)
 }
+
+func TestChangeMachinePowerState(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+
+	powerState := "ON"
+	api.handle(http.MethodGet, "/iaas/api/machines/", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "machine-1", "powerState": powerState, "_links": map[string]interface{}{}})
+	})
+	api.handle(http.MethodPost, "/iaas/api/machines/machine-1/operations/power-off", func(w http.ResponseWriter, r *http.Request) {
+		powerState = "OFF"
+		api.writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "tracker", "status": "INPROGRESS", "progress": 0, "selfLink": "/iaas/api/request-tracker/tracker"})
+	})
+	api.handle(http.MethodGet, "/iaas/api/request-tracker/", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "tracker", "status": "FINISHED", "progress": 100,
+			"resources": []interface{}{"/iaas/api/machines/machine-1"}, "selfLink": r.URL.Path})
+	})
+
+	var tests = []struct {
+		current    string
+		desired    string
+		operations int
+	}{
+		{"ON", "ON", 0},
+		{"GUEST_OFF", "OFF", 0},
+		{"ON", "OFF", 1},
+	}
+
+	for _, tt := range tests {
+		powerState = tt.current
+		before := len(api.received())
+
+		if err := changeMachinePowerState(context.Background(), c.apiClient, "machine-1", tt.desired, time.Minute); err != nil {
+			t.Fatalf("%s to %s: changeMachinePowerState returned error %s", tt.current, tt.desired, err)
+		}
+
+		operations := 0
+		for _, request := range api.received()[before:] {
+			if request == "POST /iaas/api/machines/machine-1/operations/power-off" {
+				operations++
+			}
+		}
+		if operations != tt.operations {
+			t.Errorf("%s to %s: expected %d power operations, got %d", tt.current, tt.desired, tt.operations, operations)
+		}
+		if tt.operations > 0 && powerState != tt.desired {
+			t.Errorf("%s to %s: expected the machine to be %s, got %s", tt.current, tt.desired, tt.desired, powerState)
+		}
+	}
+}
+
+func TestExpandDisks_order(t *testing.T) {
+	configDisks := []interface{}{
+		map[string]interface{}{"block_device_id": "disk-a", "name": "", "description": "", "scsi_controller": "", "unit_number": ""},
+		map[string]interface{}{"block_device_id": "disk-b", "name": "", "description": "", "scsi_controller": "SCSI_Controller_1", "unit_number": "0"},
+		map[string]interface{}{"block_device_id": "disk-c", "name": "", "description": "", "scsi_controller": "SCSI_Controller_0", "unit_number": "10"},
+		map[string]interface{}{"block_device_id": "disk-d", "name": "", "description": "", "scsi_controller": "SCSI_Controller_0", "unit_number": "2"},
+	}
+
+	disks := expandDisks(configDisks)
+
+	expected := []string{"disk-d", "disk-c", "disk-b", "disk-a"}
+	for i, disk := range disks {
+		if *disk.BlockDeviceID != expected[i] {
+			t.Fatalf("expected the disks to be attached in the order %v, disk %d is %s", expected, i, *disk.BlockDeviceID)
+		}
+	}
+	if disks[0].ScsiController != "SCSI_Controller_0" || disks[0].UnitNumber != "2" {
+		t.Errorf("expected disk-d on SCSI_Controller_0 unit 2, got %q unit %q", disks[0].ScsiController, disks[0].UnitNumber)
+	}
+}
+
+func TestFilterDisks(t *testing.T) {
+	disksConfig := []interface{}{
+		map[string]interface{}{"block_device_id": "disk-a", "name": "", "description": "", "scsi_controller": "SCSI_Controller_0", "unit_number": "1"},
+	}
+	blockDevices := []*models.BlockDevice{
+		{ID: withString("boot-disk"), Name: "boot"},
+		{ID: withString("disk-a"), Name: "data"},
+	}
+
+	disks := filterDisks(disksConfig, blockDevices)
+	if len(disks) != 1 {
+		t.Fatalf("expected only the configured disk, got %v", disks)
+	}
+	disk := disks[0].(map[string]interface{})
+	if disk["scsi_controller"] != "SCSI_Controller_0" || disk["unit_number"] != "1" {
+		t.Errorf("expected the SCSI controller and unit number of the configuration, got %v", disk)
+	}
+}
@@ -0,0 +1,282 @@
+package vra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/request"
+	"github.com/vmware/vra-sdk-go/pkg/client/security_group"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSecurityGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecurityGroupCreate,
+		ReadContext:   resourceSecurityGroupRead,
+		UpdateContext: resourceSecurityGroupUpdate,
+		DeleteContext: resourceSecurityGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "A human-friendly name used as an identifier in APIs that support this option.",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the project the security group belongs to.",
+			},
+			"custom_properties": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Description: "Additional properties that may be used to extend the security group.",
+			},
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The id of the deployment that is associated with this resource.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A human-friendly description.",
+			},
+			"rules": rulesSchema(false),
+			"tags":  tagsSchema(),
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"external_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"external_region_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"external_zone_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"links": linksSchema(),
+			"organization_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func expandSecurityGroupSpecification(d *schema.ResourceData) *models.SecurityGroupSpecification {
+	name := d.Get("name").(string)
+	projectID := d.Get("project_id").(string)
+
+	return &models.SecurityGroupSpecification{
+		CustomProperties: expandCustomProperties(d.Get("custom_properties").(map[string]interface{})),
+		DeploymentID:     d.Get("deployment_id").(string),
+		Description:      d.Get("description").(string),
+		Name:             &name,
+		ProjectID:        &projectID,
+		Rules:            expandRules(d.Get("rules").(*schema.Set).List()),
+		Tags:             expandTags(d.Get("tags").(*schema.Set).List()),
+	}
+}
+
+func resourceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("Starting to create vra_security_group resource")
+	apiClient := m.(*Client).apiClient
+
+	securityGroupSpecification := expandSecurityGroupSpecification(d)
+
+	log.Printf("[DEBUG] create security group: %#v", securityGroupSpecification)
+	createSecurityGroupAccepted, err := apiClient.SecurityGroup.CreateOnDemandSecurityGroup(security_group.NewCreateOnDemandSecurityGroupParams().WithBody(securityGroupSpecification))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:      5 * time.Second,
+		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
+		Refresh:    securityGroupStateRefreshFunc(*apiClient, *createSecurityGroupAccepted.Payload.ID),
+		Target:     []string{models.RequestTrackerStatusFINISHED},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 5 * time.Second,
+	}
+
+	resourceIds, err := stateChangeFunc.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	securityGroupIDs := resourceIds.([]string)
+	if len(securityGroupIDs) == 0 || securityGroupIDs[0] == "" {
+		return diag.Errorf("security group %s was requested, but the request did not return its id", d.Get("name"))
+	}
+	d.SetId(securityGroupIDs[0])
+	log.Printf("Finished to create vra_security_group resource with name %s", d.Get("name"))
+
+	return resourceSecurityGroupRead(ctx, d, m)
+}
+
+func securityGroupStateRefreshFunc(apiClient client.MulticloudIaaS, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ret, err := apiClient.Request.GetRequestTracker(request.NewGetRequestTrackerParams().WithID(id))
+		if err != nil {
+			return "", models.RequestTrackerStatusFAILED, err
+		}
+
+		status := ret.Payload.Status
+		switch *status {
+		case models.RequestTrackerStatusFAILED:
+			return []string{""}, *status, fmt.Errorf(ret.Payload.Message)
+		case models.RequestTrackerStatusINPROGRESS:
+			return [...]string{id}, *status, nil
+		case models.RequestTrackerStatusFINISHED:
+			securityGroupIDs := make([]string, len(ret.Payload.Resources))
+			for i, r := range ret.Payload.Resources {
+				securityGroupIDs[i] = strings.TrimPrefix(r, "/iaas/api/security-groups/")
+			}
+			return securityGroupIDs, *status, nil
+		default:
+			return [...]string{id}, ret.Payload.Message, fmt.Errorf("securityGroupStateRefreshFunc: unknown status %v", *status)
+		}
+	}
+}
+
+func resourceSecurityGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("Reading the vra_security_group resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	resp, err := apiClient.SecurityGroup.GetSecurityGroup(security_group.NewGetSecurityGroupParams().WithID(d.Id()))
+	if err != nil {
+		switch err.(type) {
+		case *security_group.GetSecurityGroupNotFound:
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	securityGroup := *resp.Payload
+	d.Set("created_at", securityGroup.CreatedAt)
+	d.Set("custom_properties", securityGroup.CustomProperties)
+	d.Set("deployment_id", securityGroup.DeploymentID)
+	d.Set("description", securityGroup.Description)
+	d.Set("external_id", securityGroup.ExternalID)
+	d.Set("external_region_id", securityGroup.ExternalRegionID)
+	d.Set("external_zone_id", securityGroup.ExternalZoneID)
+	d.Set("name", securityGroup.Name)
+	d.Set("organization_id", securityGroup.OrganizationID)
+	d.Set("owner", securityGroup.Owner)
+	d.Set("project_id", securityGroup.ProjectID)
+	d.Set("updated_at", securityGroup.UpdatedAt)
+
+	if err := d.Set("rules", flattenRules(securityGroup.Rules)); err != nil {
+		return diag.Errorf("error setting security group rules - error: %v", err)
+	}
+
+	if err := d.Set("tags", flattenTags(securityGroup.Tags)); err != nil {
+		return diag.Errorf("error setting security group tags - error: %v", err)
+	}
+
+	if err := d.Set("links", flattenLinks(securityGroup.Links)); err != nil {
+		return diag.Errorf("error setting security group links - error: %#v", err)
+	}
+
+	log.Printf("Finished reading the vra_security_group resource with name %s", d.Get("name"))
+	return nil
+}
+
+func resourceSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("Starting to update the vra_security_group resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	// The reconfigure day-2 action replaces the whole specification of the security group, including its rules
+	securityGroupSpecification := expandSecurityGroupSpecification(d)
+
+	log.Printf("[DEBUG] reconfigure security group: %#v", securityGroupSpecification)
+	reconfigureSecurityGroupAccepted, err := apiClient.SecurityGroup.ReconfigureSecurityGroup(security_group.NewReconfigureSecurityGroupParams().WithID(d.Id()).WithBody(securityGroupSpecification))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:      5 * time.Second,
+		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
+		Refresh:    securityGroupStateRefreshFunc(*apiClient, *reconfigureSecurityGroupAccepted.Payload.ID),
+		Target:     []string{models.RequestTrackerStatusFINISHED},
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateChangeFunc.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Finished updating the vra_security_group resource with name %s", d.Get("name"))
+	return resourceSecurityGroupRead(ctx, d, m)
+}
+
+func resourceSecurityGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("Starting to delete the vra_security_group resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	deleteSecurityGroupAccepted, deleteSecurityGroupNoContent, err := apiClient.SecurityGroup.DeleteSecurityGroup(security_group.NewDeleteSecurityGroupParams().WithID(d.Id()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Handle non-request tracker case
+	if deleteSecurityGroupNoContent != nil {
+		d.SetId("")
+		return nil
+	}
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:      5 * time.Second,
+		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
+		Refresh:    securityGroupStateRefreshFunc(*apiClient, *deleteSecurityGroupAccepted.Payload.ID),
+		Target:     []string{models.RequestTrackerStatusFINISHED},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateChangeFunc.WaitForStateContext(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Printf("Finished deleting the vra_security_group resource with name %s", d.Get("name"))
+	return nil
+}
@@ -0,0 +1,67 @@
+package vra
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceSecurityGroup_Create(t *testing.T) {
+	api := newTestMockAPI(t)
+	c := api.client()
+
+	var rules []interface{}
+	api.handle(http.MethodPost, "/iaas/api/security-groups", func(w http.ResponseWriter, r *http.Request) {
+		spec := api.readJSON(r)
+		if spec["projectId"] != "project-1" {
+			t.Errorf("expected the security group in project-1, got %v", spec["projectId"])
+		}
+		rules, _ = spec["rules"].([]interface{})
+		api.writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "tracker", "status": "INPROGRESS", "progress": 0, "selfLink": "/iaas/api/request-tracker/tracker"})
+	})
+	api.handle(http.MethodGet, "/iaas/api/request-tracker/", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "tracker", "status": "FINISHED", "progress": 100,
+			"resources": []interface{}{"/iaas/api/security-groups/sg-1"}, "selfLink": r.URL.Path})
+	})
+	api.handle(http.MethodGet, "/iaas/api/security-groups/sg-1", func(w http.ResponseWriter, r *http.Request) {
+		api.writeJSON(w, http.StatusOK, map[string]interface{}{"id": "sg-1", "name": "web", "projectId": "project-1",
+			"rules": rules, "_links": map[string]interface{}{}})
+	})
+
+	r := resourceSecurityGroup()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":       "web",
+		"project_id": "project-1",
+		"rules": []interface{}{
+			map[string]interface{}{
+				"access":        "Allow",
+				"direction":     "Inbound",
+				"ip_range_cidr": "10.0.0.0/24",
+				"ports":         "443",
+				"protocol":      "TCP",
+			},
+		},
+	})
+
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create returned error %v", diags)
+	}
+	if d.Id() != "sg-1" {
+		t.Errorf("expected the created security group sg-1, got %q", d.Id())
+	}
+
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule to be sent, got %v", rules)
+	}
+	rule := rules[0].(map[string]interface{})
+	if rule["access"] != "Allow" || rule["direction"] != "Inbound" || rule["ipRangeCidr"] != "10.0.0.0/24" || rule["ports"] != "443" || rule["protocol"] != "TCP" {
+		t.Errorf("unexpected rule sent %v", rule)
+	}
+
+	stateRules := d.Get("rules").(*schema.Set).List()
+	if len(stateRules) != 1 || stateRules[0].(map[string]interface{})["ip_range_cidr"] != "10.0.0.0/24" {
+		t.Errorf("unexpected rules in state %v", stateRules)
+	}
+}
@@ -0,0 +1,90 @@
+package vra
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// testDeploymentResourcesJSON is a trimmed down resources payload of a deployment with two machines and a network
+const testDeploymentResourcesJSON = `[
+  {
+    "id": "6b7e4bd4-8ca3-4c56-b8a1-3d0d0c6b0b0e",
+    "name": "web",
+    "type": "Cloud.vSphere.Machine",
+    "properties": {
+      "address": "10.0.0.11",
+      "powerState": "ON",
+      "cpuCount": 2
+    }
+  },
+  {
+    "id": "0c1d9b9f-2f1e-4a55-9d7c-4b4c3e8c4f21",
+    "name": "net",
+    "type": "Cloud.vSphere.Network",
+    "properties": {
+      "networkType": "existing"
+    }
+  },
+  {
+    "id": "2a1f2d84-44b6-4b0d-a3a4-8e7b7b1f5a9c",
+    "name": "db",
+    "type": "Cloud.vSphere.Machine",
+    "properties": {
+      "address": "10.0.0.12",
+      "powerState": "OFF"
+    }
+  }
+]`
+
+func TestFlattenResourceAddresses(t *testing.T) {
+	if addresses := flattenResourceAddresses(nil); len(addresses) != 0 {
+		t.Errorf("expected no resource addresses without resources, got %#v", addresses)
+	}
+
+	var resources []*models.DeploymentResource
+	if err := json.Unmarshal([]byte(testDeploymentResourcesJSON), &resources); err != nil {
+		t.Fatalf("error unmarshalling the deployment resources fixture: %s", err)
+	}
+
+	addresses := flattenResourceAddresses(resources)
+	expected := map[string]interface{}{
+		"db":  "10.0.0.12",
+		"net": "",
+		"web": "10.0.0.11",
+	}
+	if len(addresses) != len(expected) {
+		t.Fatalf("expected %d resource addresses, got %#v", len(expected), addresses)
+	}
+	for name, address := range expected {
+		if addresses[name] != address {
+			t.Errorf("expected the address of %s to be %q, got %q", name, address, addresses[name])
+		}
+	}
+}
+
+func TestFlattenResourcesByType(t *testing.T) {
+	if resourcesByType := flattenResourcesByType(nil); len(resourcesByType) != 0 {
+		t.Errorf("expected no resource types without resources, got %#v", resourcesByType)
+	}
+
+	var resources []*models.DeploymentResource
+	if err := json.Unmarshal([]byte(testDeploymentResourcesJSON), &resources); err != nil {
+		t.Fatalf("error unmarshalling the deployment resources fixture: %s", err)
+	}
+
+	resourcesByType := flattenResourcesByType(resources)
+	expected := map[string]interface{}{
+		"Cloud.vSphere.Machine": `["db","web"]`,
+		"Cloud.vSphere.Network": `["net"]`,
+	}
+	if len(resourcesByType) != len(expected) {
+		t.Fatalf("expected %d resource types, got %#v", len(expected), resourcesByType)
+	}
+	for resourceType, names := range expected {
+		if resourcesByType[resourceType] != names {
+			t.Errorf("expected the %s resources %s, got %v", resourceType, names, resourcesByType[resourceType])
+		}
+	}
+}
@@ -2,6 +2,8 @@ package vra
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
 // rulesSchema returns the schema to use for the rules property
@@ -13,39 +15,46 @@ func rulesSchema(isRequired bool) *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"access": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Type of access for the traffic matching the rule: Allow, Deny or Drop.",
+					ValidateFunc: validation.StringInSlice([]string{"Allow", "Deny", "Drop"}, false),
 				},
 				"direction": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Direction of the traffic the rule applies to: Inbound or Outbound.",
+					ValidateFunc: validation.StringInSlice([]string{"Inbound", "Outbound"}, false),
 				},
 				"ip_range_cidr": {
-					Type:     schema.TypeInt,
-					Required: true,
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "IP range, in CIDR format, the traffic comes from (Inbound) or goes to (Outbound), for example 66.170.99.2/32.",
 				},
 				"name": {
 					Type:     schema.TypeString,
 					Optional: true,
 				},
 				"ports": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Ports the rule applies to, for example 443 or 1-65535.",
 				},
 				"protocol": {
-					Type:     schema.TypeString,
-					Required: true,
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Protocol the rule applies to, for example ANY, TCP or UDP.",
 				},
 				"service": {
-					Type:     schema.TypeString,
-					Optional: true,
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Service the rule applies to, for example HTTPS or SSH.",
 				},
 			},
 		},
 	}
 }
 
-/*
 func expandRules(configRules []interface{}) []*models.Rule {
 	rules := make([]*models.Rule, 0, len(configRules))
 
@@ -54,18 +63,45 @@ func expandRules(configRules []interface{}) []*models.Rule {
 
 		rule := models.Rule{
 			Access:      withString(ruleMap["access"].(string)),
+			Direction:   withString(ruleMap["direction"].(string)),
 			IPRangeCidr: withString(ruleMap["ip_range_cidr"].(string)),
 			Ports:       withString(ruleMap["ports"].(string)),
-			Protocol:    withString(ruleMap["protocol"].(string)),
+			Protocol:    ruleMap["protocol"].(string),
 		}
 
 		if v, ok := ruleMap["name"].(string); ok && v != "" {
 			rule.Name = v
 		}
 
+		if v, ok := ruleMap["service"].(string); ok && v != "" {
+			rule.Service = v
+		}
+
 		rules = append(rules, &rule)
 	}
 
 	return rules
 }
-*/
+
+func flattenRules(rules []*models.Rule) []interface{} {
+	if len(rules) == 0 {
+		return make([]interface{}, 0)
+	}
+
+	configRules := make([]interface{}, 0, len(rules))
+
+	for _, rule := range rules {
+		helper := make(map[string]interface{})
+		helper["access"] = rule.Access
+		helper["direction"] = rule.Direction
+		helper["ip_range_cidr"] = rule.IPRangeCidr
+		helper["name"] = rule.Name
+		helper["ports"] = rule.Ports
+		helper["protocol"] = rule.Protocol
+		helper["service"] = rule.Service
+
+		configRules = append(configRules, helper)
+	}
+
+	return configRules
+}
@@ -4,9 +4,15 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
+// resourceGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type resourceGetter interface {
+	Get(key string) interface{}
+}
+
 // withString will return a string pointer of the passed in string value
 func withString(s string) *string {
 	return &s
@@ -16,6 +22,10 @@ func withBool(b bool) *bool {
 	return &b
 }
 
+func withInt32(i int32) *int32 {
+	return &i
+}
+
 // expandStringList will convert the interface list into a list of strings
 func expandStringList(slist []interface{}) []string {
 	vs := make([]string, 0, len(slist))
@@ -64,6 +74,22 @@ func indexOf(value string, items []string) (int, error) {
 	return -1, fmt.Errorf("Could not find %s in item list %v", value, items)
 }
 
+// validateRegionsDiscoverable will return an error listing the valid options if any region is not discoverable
+func validateRegionsDiscoverable(regions, discoverable []string) error {
+	var unknown []string
+	for _, region := range regions {
+		if _, err := indexOf(region, discoverable); err != nil {
+			unknown = append(unknown, region)
+		}
+	}
+
+	if len(unknown) != 0 {
+		return fmt.Errorf("regions %s are not discoverable, valid regions are %s", strings.Join(unknown, ", "), strings.Join(discoverable, ", "))
+	}
+
+	return nil
+}
+
 // flattenAndNormalizeCloudAccountRegionIds will return region id's in the same order as regionOrder
 func flattenAndNormalizeCloudAccountRegionIds(regionOrder []string, cloudAccount *models.CloudAccount) ([]string, error) {
 	returnOrder := cloudAccount.EnabledRegionIds
@@ -124,6 +150,29 @@ func flattenAndNormalizeCloudAccountVsphereRegionIds(regionOrder []string, cloud
 	return m, nil
 }
 
+// flattenCloudAccountVsphereRegionIDMap will return a map of the external region ids to the region ids of the cloud account
+func flattenCloudAccountVsphereRegionIDMap(cloudAccount *models.CloudAccountVsphere) map[string]string {
+	refStrings := cloudAccount.Links["regions"].Hrefs
+	m := make(map[string]string, len(cloudAccount.EnabledRegionIds))
+	for i, r := range cloudAccount.EnabledRegionIds {
+		if i >= len(refStrings) {
+			break
+		}
+		m[r] = strings.TrimPrefix(refStrings[i], "/iaas/api/regions/")
+	}
+	return m
+}
+
+// equivalentHostnames returns true if the hostnames only differ in case or by a trailing dot
+func equivalentHostnames(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// suppressEquivalentHostnameDiff is a DiffSuppressFunc for hostnames that ignores differences of case and trailing dots
+func suppressEquivalentHostnameDiff(k, old, new string, d *schema.ResourceData) bool {
+	return equivalentHostnames(old, new)
+}
+
 // flattenAssociatedCloudAccountIds will return associated cloud account ids from the Href links in the order received
 func flattenAssociatedCloudAccountIds(links map[string]models.Href) []string {
 	refStrings := links["associated-cloud-accounts"].Hrefs
@@ -2,6 +2,8 @@ package vra
 
 import (
 	"testing"
+
+	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
 func TestExpandInputs(t *testing.T) {
@@ -41,3 +43,68 @@ func TestExpandInputs(t *testing.T) {
 		t.Errorf("object type input is not expanded correctly.")
 	}
 }
+
+func TestValidateRegionsDiscoverable(t *testing.T) {
+	discoverable := []string{"Datacenter:datacenter-2", "Datacenter:datacenter-3"}
+
+	if err := validateRegionsDiscoverable([]string{"Datacenter:datacenter-2"}, discoverable); err != nil {
+		t.Errorf("expected discoverable region to be valid, got %s", err)
+	}
+
+	if err := validateRegionsDiscoverable([]string{}, discoverable); err != nil {
+		t.Errorf("expected no regions to be valid, got %s", err)
+	}
+
+	err := validateRegionsDiscoverable([]string{"Datacenter:datacenter-2", "Datacenter:datacenter-22"}, discoverable)
+	if err == nil {
+		t.Fatalf("expected an error for a region that is not discoverable")
+	}
+
+	expected := "regions Datacenter:datacenter-22 are not discoverable, valid regions are Datacenter:datacenter-2, Datacenter:datacenter-3"
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestFlattenCloudAccountVsphereRegionIDMap(t *testing.T) {
+	cloudAccount := &models.CloudAccountVsphere{
+		EnabledRegionIds: []string{"Datacenter:datacenter-2", "Datacenter:datacenter-3"},
+		Links: map[string]models.Href{
+			"regions": {Hrefs: []string{"/iaas/api/regions/9e49e0c3", "/iaas/api/regions/f2d1b6a5"}},
+		},
+	}
+
+	regionIDMap := flattenCloudAccountVsphereRegionIDMap(cloudAccount)
+	if len(regionIDMap) != 2 {
+		t.Fatalf("expected 2 regions in the map, got %#v", regionIDMap)
+	}
+	if regionIDMap["Datacenter:datacenter-2"] != "9e49e0c3" || regionIDMap["Datacenter:datacenter-3"] != "f2d1b6a5" {
+		t.Errorf("region ids are not mapped correctly: %#v", regionIDMap)
+	}
+
+	if regionIDMap := flattenCloudAccountVsphereRegionIDMap(&models.CloudAccountVsphere{}); len(regionIDMap) != 0 {
+		t.Errorf("expected an empty map without regions, got %#v", regionIDMap)
+	}
+}
+
+func TestSuppressEquivalentHostnameDiff(t *testing.T) {
+	var tests = []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"vcenter.corp", "vcenter.corp", true},
+		{"vcenter.corp", "VCENTER.Corp.", true},
+		{"VCenter.corp.", "vcenter.corp", true},
+		{"vcenter.corp", "vcenter2.corp", false},
+		{"vcenter.corp", "vcenter.corp.local", false},
+		{"vcenter.corp", "vcenter.corp..", false},
+		{"", "vcenter.corp", false},
+	}
+
+	for _, tt := range tests {
+		if actual := suppressEquivalentHostnameDiff("hostname", tt.old, tt.new, nil); actual != tt.suppress {
+			t.Errorf("hostnames %q and %q: expected suppress %t, got %t", tt.old, tt.new, tt.suppress, actual)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package vra
 
 import (
+	"sort"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
@@ -19,13 +21,60 @@ func tagsSchema() *schema.Schema {
 				},
 				"value": {
 					Type:     schema.TypeString,
-					Required: true,
+					Optional: true,
 				},
 			},
 		},
 	}
 }
 
+// cloudAccountTagsSchema returns the schema to use for the tags property of the cloud account resources. Unlike
+// tagsSchema it is not computed, so that removing all the tags from the configuration clears them.
+func cloudAccountTagsSchema() *schema.Schema {
+	tags := tagsSchema()
+	tags.Computed = false
+	tags.ConflictsWith = []string{"tags_map"}
+	return tags
+}
+
+// cloudAccountTagsMapSchema returns the schema to use for the tags_map property of the cloud account resources, which
+// sets the same tags as the tags property as a map of keys to values
+func cloudAccountTagsMapSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeMap,
+		Optional:      true,
+		ConflictsWith: []string{"tags"},
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// expandCloudAccountTags returns the tags of a cloud account configured with either tags or tags_map
+func expandCloudAccountTags(d *schema.ResourceData) []*models.Tag {
+	if v, ok := d.GetOk("tags_map"); ok {
+		return expandTagsMap(v.(map[string]interface{}))
+	}
+	return expandTags(d.Get("tags").(*schema.Set).List())
+}
+
+// setCloudAccountTags sets the tags of a cloud account in the attribute the tags are managed with. The tags are set in
+// tags_map only when it is in use, so that the configuration using tags does not show a diff for tags_map, and the
+// other way round.
+func setCloudAccountTags(d *schema.ResourceData, tags []*models.Tag) error {
+	if d.Get("tags").(*schema.Set).Len() == 0 && len(d.Get("tags_map").(map[string]interface{})) > 0 {
+		if err := d.Set("tags", flattenTags(nil)); err != nil {
+			return err
+		}
+		return d.Set("tags_map", flattenTagsMap(tags))
+	}
+
+	if err := d.Set("tags_map", nil); err != nil {
+		return err
+	}
+	return d.Set("tags", flattenTags(tags))
+}
+
 func expandTags(configTags []interface{}) []*models.Tag {
 	tags := make([]*models.Tag, 0, len(configTags))
 
@@ -33,8 +82,12 @@ func expandTags(configTags []interface{}) []*models.Tag {
 		tagMap := configTag.(map[string]interface{})
 
 		tag := models.Tag{
-			Key:   withString(tagMap["key"].(string)),
-			Value: withString(tagMap["value"].(string)),
+			Key: withString(tagMap["key"].(string)),
+		}
+
+		// Tags with only a key are sent without a value
+		if v, ok := tagMap["value"].(string); ok && v != "" {
+			tag.Value = withString(v)
 		}
 
 		tags = append(tags, &tag)
@@ -52,11 +105,62 @@ func flattenTags(tags []*models.Tag) []interface{} {
 
 	for _, tag := range tags {
 		helper := make(map[string]interface{})
-		helper["key"] = tag.Key
-		helper["value"] = tag.Value
+		helper["key"] = ""
+		helper["value"] = ""
+
+		if tag.Key != nil {
+			helper["key"] = *tag.Key
+		}
+
+		if tag.Value != nil {
+			helper["value"] = *tag.Value
+		}
 
 		configTags = append(configTags, helper)
 	}
 
 	return configTags
 }
+
+// expandTagsMap returns the tags of a map of keys to values, sorted by key. Like with expandTags, tags with an empty
+// value are sent without a value.
+func expandTagsMap(configTags map[string]interface{}) []*models.Tag {
+	keys := make([]string, 0, len(configTags))
+	for key := range configTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tags := make([]*models.Tag, 0, len(keys))
+	for _, key := range keys {
+		tag := models.Tag{
+			Key: withString(key),
+		}
+
+		if v, ok := configTags[key].(string); ok && v != "" {
+			tag.Value = withString(v)
+		}
+
+		tags = append(tags, &tag)
+	}
+
+	return tags
+}
+
+func flattenTagsMap(tags []*models.Tag) map[string]interface{} {
+	configTags := make(map[string]interface{}, len(tags))
+
+	for _, tag := range tags {
+		if tag.Key == nil {
+			continue
+		}
+
+		value := ""
+		if tag.Value != nil {
+			value = *tag.Value
+		}
+		configTags[*tag.Key] = value
+	}
+
+	return configTags
+}
@@ -0,0 +1,139 @@
+package vra
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestExpandTags(t *testing.T) {
+	t1 := map[string]interface{}{"key": "foo", "value": "bar"}
+	t2 := map[string]interface{}{"key": "keyless", "value": ""}
+	t3 := map[string]interface{}{"key": "novalue"}
+
+	expandedTags := expandTags([]interface{}{t1, t2, t3})
+
+	if len(expandedTags) != 3 {
+		t.Fatalf("not all tags are expanded correctly")
+	}
+
+	if *expandedTags[0].Key != "foo" || expandedTags[0].Value == nil || *expandedTags[0].Value != "bar" {
+		t.Errorf("tag %#v is not expanded correctly", t1)
+	}
+
+	if *expandedTags[1].Key != "keyless" || expandedTags[1].Value != nil {
+		t.Errorf("keyless tag %#v is not expanded correctly", t2)
+	}
+
+	if *expandedTags[2].Key != "novalue" || expandedTags[2].Value != nil {
+		t.Errorf("keyless tag %#v is not expanded correctly", t3)
+	}
+}
+
+func TestFlattenTags(t *testing.T) {
+	if len(flattenTags(nil)) != 0 {
+		t.Errorf("error while flattening when there are no tags")
+	}
+
+	tags := []*models.Tag{
+		{Key: withString("foo"), Value: withString("bar")},
+		{Key: withString("keyless")},
+		{Key: withString("empty"), Value: withString("")},
+	}
+
+	flattenedTags := flattenTags(tags)
+
+	if len(flattenedTags) != 3 {
+		t.Fatalf("not all tags are flattened correctly")
+	}
+
+	expected := []map[string]interface{}{
+		{"key": "foo", "value": "bar"},
+		{"key": "keyless", "value": ""},
+		{"key": "empty", "value": ""},
+	}
+
+	for i, e := range expected {
+		ft := flattenedTags[i].(map[string]interface{})
+		if ft["key"] != e["key"] || ft["value"] != e["value"] {
+			t.Errorf("tag %#v is not flattened correctly, expected %#v", ft, e)
+		}
+	}
+
+	// A keyless tag must round trip to the same value as configured, so no diff is produced
+	roundTrip := flattenTags(expandTags([]interface{}{map[string]interface{}{"key": "keyless", "value": ""}}))
+	if roundTrip[0].(map[string]interface{})["value"] != "" {
+		t.Errorf("keyless tag does not round trip, got %#v", roundTrip[0])
+	}
+}
+
+func TestExpandTagsEmpty(t *testing.T) {
+	expandedTags := expandTags([]interface{}{})
+
+	if expandedTags == nil || len(expandedTags) != 0 {
+		t.Fatalf("expected an empty, non-nil list of tags, got %#v", expandedTags)
+	}
+
+	b, err := json.Marshal(&models.UpdateCloudAccountVsphereSpecification{Tags: expandedTags})
+	if err != nil {
+		t.Fatalf("error marshalling the update specification: %s", err)
+	}
+
+	if !strings.Contains(string(b), `"tags":[]`) {
+		t.Errorf("expected empty tags to be sent as an empty list to clear them, got %s", b)
+	}
+}
+
+func TestCloudAccountTagsSchema(t *testing.T) {
+	if !tagsSchema().Computed {
+		t.Error("expected the shared tags schema to stay computed for the tags set by the API")
+	}
+
+	for name, r := range map[string]*schema.Resource{
+		"vra_cloud_account_aws":     resourceCloudAccountAWS(),
+		"vra_cloud_account_vsphere": resourceCloudAccountVsphere(),
+	} {
+		if r.Schema["tags"].Computed {
+			t.Errorf("expected the tags of %s not to be computed, so that removing them clears them", name)
+		}
+	}
+}
+
+func TestExpandTagsMap(t *testing.T) {
+	expandedTags := expandTagsMap(map[string]interface{}{"foo": "bar", "keyless": "", "alpha": "beta"})
+
+	expected := []map[string]interface{}{
+		{"key": "alpha", "value": "beta"},
+		{"key": "foo", "value": "bar"},
+		{"key": "keyless", "value": ""},
+	}
+	flattenedTags := flattenTags(expandedTags)
+	if len(flattenedTags) != len(expected) {
+		t.Fatalf("expected %d tags, got %d", len(expected), len(flattenedTags))
+	}
+	for i, e := range expected {
+		ft := flattenedTags[i].(map[string]interface{})
+		if ft["key"] != e["key"] || ft["value"] != e["value"] {
+			t.Errorf("tag %#v is not expanded correctly, expected %#v", ft, e)
+		}
+	}
+
+	if expandedTags[2].Value != nil {
+		t.Errorf("expected the keyless tag to be sent without a value")
+	}
+}
+
+func TestFlattenTagsMap(t *testing.T) {
+	if len(flattenTagsMap(nil)) != 0 {
+		t.Errorf("error while flattening when there are no tags")
+	}
+
+	tagsMap := map[string]interface{}{"foo": "bar", "keyless": ""}
+	if roundTrip := flattenTagsMap(expandTagsMap(tagsMap)); !reflect.DeepEqual(roundTrip, tagsMap) {
+		t.Errorf("expected the tags map %v to round trip, got %v", tagsMap, roundTrip)
+	}
+}
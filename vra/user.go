@@ -2,6 +2,7 @@ package vra
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
@@ -14,10 +15,11 @@ func userSchema(description string) *schema.Schema {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"type": {
-					Type:        schema.TypeString,
-					Default:     "user",
-					Optional:    true,
-					Description: "Type of the principal. Currently supported ‘user’ (default) and 'group’.",
+					Type:         schema.TypeString,
+					Default:      "user",
+					Optional:     true,
+					Description:  "Type of the principal. Currently supported ‘user’ (default) and 'group’.",
+					ValidateFunc: validation.StringInSlice([]string{"user", "group"}, false),
 				},
 				"email": {
 					Type:        schema.TypeString,
@@ -0,0 +1,41 @@
+package vra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestUserSchema_type(t *testing.T) {
+	userType := userSchema("").Elem.(*schema.Resource).Schema["type"]
+
+	for _, v := range []string{"user", "group"} {
+		if _, errs := userType.ValidateFunc(v, "type"); len(errs) != 0 {
+			t.Errorf("expected %q to be a valid type, got %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "groups", "Group", "role"} {
+		if _, errs := userType.ValidateFunc(v, "type"); len(errs) == 0 {
+			t.Errorf("expected %q to be an invalid type", v)
+		}
+	}
+}
+
+func TestExpandFlattenUsers(t *testing.T) {
+	configUsers := []interface{}{
+		map[string]interface{}{"email": "jdoe@example.com", "type": "user"},
+		map[string]interface{}{"email": "devs@example.com", "type": "group"},
+	}
+
+	users := expandUsers(configUsers)
+	if len(users) != 2 || users[1].Type != "group" || *users[1].Email != "devs@example.com" {
+		t.Fatalf("expandUsers returned unexpected users %#v", users)
+	}
+
+	// A user and a group with the same name are different principals
+	set := schema.NewSet(schema.HashResource(userSchema("").Elem.(*schema.Resource)), flattenUsers(users))
+	set.Add(map[string]interface{}{"email": "devs@example.com", "type": "user"})
+	if set.Len() != 3 {
+		t.Errorf("expected a user and a group with the same name to be distinct, got %d principals", set.Len())
+	}
+}